@@ -0,0 +1,105 @@
+package healthy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// BannerCheck represents a server banner health check.
+type BannerCheck struct {
+	typ     string
+	addr    string
+	re      *regexp.Regexp
+	timeout time.Duration
+}
+
+const bannerMaxLineSize = 4096
+
+var (
+	ftpBanner = regexp.MustCompile(`^220[ -]`)
+	sshBanner = regexp.MustCompile(`^SSH-2\.0-`)
+)
+
+// Banner returns a server banner health check.
+// The check reads the greeting line sent by the server on connect and
+// returns nil if it matches the regular expression.
+func Banner(addr string, re *regexp.Regexp) *BannerCheck {
+	return &BannerCheck{
+		typ:     "banner",
+		addr:    addr,
+		re:      re,
+		timeout: time.Second,
+	}
+}
+
+// FTP returns an FTP server health check.
+// The check returns nil once the server sends a 220 service ready greeting.
+func FTP(addr string) *BannerCheck {
+	c := Banner(withDefaultPort(addr, "21"), ftpBanner)
+	c.typ = "ftp"
+	return c
+}
+
+// SSH returns an SSH server health check.
+// The check returns nil once the server sends an SSH 2.0 identification
+// string.
+func SSH(addr string) *BannerCheck {
+	c := Banner(withDefaultPort(addr, "22"), sshBanner)
+	c.typ = "ssh"
+	return c
+}
+
+// Timeout specifies the connection timeout.
+func (c *BannerCheck) Timeout(t time.Duration) *BannerCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the server greeting matches the regular
+// expression. The greeting is stored in the context metadata.
+func (c *BannerCheck) Healthy(ctx context.Context) error {
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	line, err := readLine(bufio.NewReaderSize(conn, bannerMaxLineSize))
+	if err != nil {
+		return err
+	}
+	GetContextMetadata(ctx).Set("banner", line)
+
+	if !c.re.MatchString(line) {
+		return fmt.Errorf("unexpected banner: %q", line)
+	}
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *BannerCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    c.typ,
+		"target":  c.addr,
+		"matches": c.re.String(),
+		"timeout": c.timeout.String(),
+	}
+}
+
+// readLine reads a line from a text protocol, trimming the line ending.
+// An error is returned if the line exceeds the reader buffer size.
+func readLine(r *bufio.Reader) (string, error) {
+	b, err := r.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", errors.New("line too long")
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
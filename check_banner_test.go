@@ -0,0 +1,145 @@
+package healthy_test
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestBannerCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		banner string
+		sut    func(string) *healthy.BannerCheck
+		err    string
+	}{
+		{
+			name:   "should return nil if the banner matches",
+			banner: "+OK POP3 server ready\r\n",
+			sut: func(addr string) *healthy.BannerCheck {
+				return healthy.Banner(addr, regexp.MustCompile(`^\+OK`))
+			},
+		},
+		{
+			name:   "should return an error if the banner does not match",
+			banner: "-ERR service unavailable\r\n",
+			sut: func(addr string) *healthy.BannerCheck {
+				return healthy.Banner(addr, regexp.MustCompile(`^\+OK`))
+			},
+			err: "unexpected banner",
+		},
+		{
+			name: "should return an error if no banner is sent",
+			sut: func(addr string) *healthy.BannerCheck {
+				return healthy.Banner(addr, regexp.MustCompile(`.*`))
+			},
+			err: "EOF",
+		},
+		{
+			name:   "should return an error if the banner is too long",
+			banner: strings.Repeat("a", 5000) + "\r\n",
+			sut: func(addr string) *healthy.BannerCheck {
+				return healthy.Banner(addr, regexp.MustCompile(`.*`))
+			},
+			err: "line too long",
+		},
+		{
+			name:   "should return nil for ftp servers",
+			banner: "220 (vsFTPd 3.0.5)\r\n",
+			sut:    healthy.FTP,
+		},
+		{
+			name:   "should return nil for multi-line ftp greetings",
+			banner: "220-Welcome\r\n220 Ready\r\n",
+			sut:    healthy.FTP,
+		},
+		{
+			name:   "should return an error if the ftp server is not ready",
+			banner: "421 Too many connections\r\n",
+			sut:    healthy.FTP,
+			err:    "unexpected banner",
+		},
+		{
+			name:   "should return nil for ssh servers",
+			banner: "SSH-2.0-OpenSSH_9.6\r\n",
+			sut:    healthy.SSH,
+		},
+		{
+			name:   "should return an error for ssh 1 servers",
+			banner: "SSH-1.5-OldServer\r\n",
+			sut:    healthy.SSH,
+			err:    "unexpected banner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startBannerServer(t, tt.banner)
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			err := tt.sut(addr).Timeout(100 * time.Millisecond).Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				exp, _, _ := strings.Cut(tt.banner, "\r\n")
+				if act := md.Get("banner"); act != exp {
+					t.Errorf("got %v, expected %s", act, exp)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBanner_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.BannerCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the banner metadata",
+			sut:  healthy.Banner("mail:110", regexp.MustCompile(`^\+OK`)).Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "banner", "target": "mail:110", "matches": `^\+OK`, "timeout": "2s"},
+		},
+		{
+			name: "should return the ftp metadata",
+			sut:  healthy.FTP("ftp"),
+			exp:  healthy.Metadata{"type": "ftp", "target": "ftp:21", "matches": "^220[ -]", "timeout": "1s"},
+		},
+		{
+			name: "should return the ssh metadata",
+			sut:  healthy.SSH("sftp:2222"),
+			exp:  healthy.Metadata{"type": "ssh", "target": "sftp:2222", "matches": `^SSH-2\.0-`, "timeout": "1s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
+
+// startBannerServer starts a server that writes the banner on connect.
+func startBannerServer(t *testing.T, banner string) string {
+	return startTCPServer(t, func(conn net.Conn) {
+		io.WriteString(conn, banner)
+	})
+}
@@ -0,0 +1,131 @@
+package healthy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DirCheck represents a directory population health check.
+type DirCheck struct {
+	path    string
+	pattern string
+	min     int
+	stable  bool
+	mu      sync.Mutex
+	last    int
+}
+
+// Dir returns a directory health check.
+// By default the check returns nil if the directory exists.
+func Dir(path string) *DirCheck {
+	return &DirCheck{
+		path:    path,
+		pattern: "*",
+		last:    -1,
+	}
+}
+
+// Glob returns a directory health check that returns nil once the
+// static directory prefix of the pattern exists and at least minCount
+// paths match the pattern, for example "out/date=*/part-*.parquet".
+func Glob(pattern string, minCount int) *DirCheck {
+	dir, rest := splitGlob(pattern)
+	return Dir(dir).Match(rest).MinCount(minCount)
+}
+
+// Match specifies the pattern that entries must match, relative to the
+// directory. The pattern syntax is that of [filepath.Match] and may
+// include separators to match nested entries.
+func (c *DirCheck) Match(pattern string) *DirCheck {
+	c.pattern = pattern
+	return c
+}
+
+// MinCount specifies the minimum number of matching entries.
+func (c *DirCheck) MinCount(n int) *DirCheck {
+	c.min = n
+	return c
+}
+
+// Stable specifies that the number of matching entries must be unchanged
+// since the previous attempt, for example to wait for a writer to finish.
+func (c *DirCheck) Stable() *DirCheck {
+	c.stable = true
+	return c
+}
+
+// Healthy returns nil if the directory exists and contains the expected
+// number of matching entries.
+func (c *DirCheck) Healthy(ctx context.Context) error {
+	fi, err := os.Stat(c.path)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", c.path)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(c.path, c.pattern))
+	if err != nil {
+		return Fatal(err)
+	}
+
+	n := len(matches)
+	if n < c.min {
+		return fmt.Errorf("found %d entries matching %s, expected at least %d", n, c.pattern, c.min)
+	}
+
+	if c.stable {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		last := c.last
+		c.last = n
+		if n != last {
+			return fmt.Errorf("found %d entries matching %s, waiting for stable count", n, c.pattern)
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *DirCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "dir",
+		"target":  c.path,
+		"pattern": c.pattern,
+	}
+	if c.min > 0 {
+		md["min_count"] = strconv.Itoa(c.min)
+	}
+	if c.stable {
+		md["stable"] = "true"
+	}
+	return md
+}
+
+// splitGlob splits the pattern into the static directory prefix and the
+// remaining pattern.
+func splitGlob(pattern string) (string, string) {
+	parts := strings.Split(filepath.ToSlash(pattern), "/")
+
+	i := 0
+	for i < len(parts)-1 && !strings.ContainsAny(parts[i], `*?[\`) {
+		i++
+	}
+
+	dir := strings.Join(parts[:i], "/")
+	if dir == "" && strings.HasPrefix(pattern, "/") {
+		dir = "/"
+	} else if dir == "" {
+		dir = "."
+	}
+
+	return filepath.FromSlash(dir), filepath.FromSlash(strings.Join(parts[i:], "/"))
+}
@@ -0,0 +1,125 @@
+package healthy_test
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestDirCheck_Healthy(t *testing.T) {
+	dir := t.TempDir()
+	for _, fn := range []string{"a.csv", "b.csv", "c.txt", "p=1/part-0.csv", "p=2/part-0.csv"} {
+		fn = filepath.Join(dir, fn)
+		if err := os.MkdirAll(filepath.Dir(fn), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fn, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		sut  *healthy.DirCheck
+		err  bool
+	}{
+		{
+			name: "should return an error if the directory does not exist",
+			sut:  healthy.Dir(filepath.Join(dir, "missing")),
+			err:  true,
+		},
+		{
+			name: "should return an error if the path is not a directory",
+			sut:  healthy.Dir(filepath.Join(dir, "a.csv")),
+			err:  true,
+		},
+		{
+			name: "should return nil if the directory exists",
+			sut:  healthy.Dir(dir),
+		},
+		{
+			name: "should return an error if there are too few entries",
+			sut:  healthy.Dir(dir).Match("*.csv").MinCount(3),
+			err:  true,
+		},
+		{
+			name: "should return nil if there are enough entries",
+			sut:  healthy.Dir(dir).Match("*.csv").MinCount(2),
+		},
+		{
+			name: "should return a fatal error for invalid patterns",
+			sut:  healthy.Dir(dir).Match("[").MinCount(1),
+			err:  true,
+		},
+		{
+			name: "should return an error if there are too few glob matches",
+			sut:  healthy.Glob(filepath.Join(dir, "p=*", "part-*.csv"), 3),
+			err:  true,
+		},
+		{
+			name: "should return nil if there are enough glob matches",
+			sut:  healthy.Glob(filepath.Join(dir, "p=*", "part-*.csv"), 2),
+		},
+		{
+			name: "should return an error if the glob directory does not exist",
+			sut:  healthy.Glob(filepath.Join(dir, "missing", "*.csv"), 0),
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sut.Healthy(context.Background())
+			if (err != nil) != tt.err {
+				t.Errorf("got %v, expected error %t", err, tt.err)
+			}
+		})
+	}
+
+	t.Run("should wait for a stable count", func(t *testing.T) {
+		sut := healthy.Dir(dir).Match("*.csv").Stable()
+
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "d.csv"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+}
+
+func TestDir_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		exp := healthy.Metadata{"type": "dir", "target": "out", "pattern": "*"}
+		act := healthy.Dir("out").Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+
+	t.Run("should return the glob metadata", func(t *testing.T) {
+		exp := healthy.Metadata{
+			"type":      "dir",
+			"target":    filepath.FromSlash("/data/out"),
+			"pattern":   filepath.FromSlash("date=*/part-*.parquet"),
+			"min_count": "2",
+			"stable":    "true",
+		}
+		act := healthy.Glob("/data/out/date=*/part-*.parquet", 2).Stable().Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
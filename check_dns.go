@@ -0,0 +1,193 @@
+package healthy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNSCheck represents a DNS resolution health check.
+type DNSCheck struct {
+	name     string
+	rtype    string
+	resolver string
+	contains []string
+	min      int
+	timeout  time.Duration
+}
+
+// DNS returns a DNS resolution health check.
+// By default the name is resolved to A and AAAA records using the system
+// resolver configuration.
+func DNS(name string) *DNSCheck {
+	return &DNSCheck{
+		name:    name,
+		min:     1,
+		timeout: time.Second,
+	}
+}
+
+// Type specifies the record type to resolve.
+// Supported types are A, AAAA, CNAME, SRV and TXT.
+func (c *DNSCheck) Type(t string) *DNSCheck {
+	c.rtype = strings.ToUpper(t)
+	return c
+}
+
+// Resolver specifies the address of the DNS server, for example 10.0.0.2:53.
+func (c *DNSCheck) Resolver(addr string) *DNSCheck {
+	c.resolver = addr
+	return c
+}
+
+// Contains specifies values that the result must contain.
+// Address values are compared as IPs, SRV values as target:port and
+// CNAME values without the trailing dot.
+func (c *DNSCheck) Contains(values ...string) *DNSCheck {
+	c.contains = append(c.contains, values...)
+	return c
+}
+
+// MinRecords specifies the minimum number of records.
+// The default value is one.
+func (c *DNSCheck) MinRecords(n int) *DNSCheck {
+	c.min = n
+	return c
+}
+
+// Timeout specifies the lookup timeout. A timeout of zero means no timeout.
+func (c *DNSCheck) Timeout(t time.Duration) *DNSCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the name resolves to the expected records.
+func (c *DNSCheck) Healthy(ctx context.Context) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	records, err := c.lookup(ctx)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Errorf("%s not found: no %s records", c.name, c.recordType())
+		}
+		return err
+	}
+
+	if len(records) < c.min {
+		return fmt.Errorf("%s resolved to %d %s records, expected at least %d", c.name, len(records), c.recordType(), c.min)
+	}
+
+	for _, v := range c.contains {
+		if !slices.Contains(records, c.normalize(v)) {
+			return fmt.Errorf("%s %s records %v do not contain %s", c.name, c.recordType(), records, v)
+		}
+	}
+
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *DNSCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "dns",
+		"target":  c.name,
+		"record":  c.recordType(),
+		"timeout": c.timeout.String(),
+	}
+	if c.resolver != "" {
+		md["resolver"] = c.resolver
+	}
+	return md
+}
+
+func (c *DNSCheck) lookup(ctx context.Context) ([]string, error) {
+	r := c.newResolver()
+
+	switch c.rtype {
+	case "":
+		return lookupIP(ctx, r, "ip", c.name)
+	case "A":
+		return lookupIP(ctx, r, "ip4", c.name)
+	case "AAAA":
+		return lookupIP(ctx, r, "ip6", c.name)
+	case "CNAME":
+		cname, err := r.LookupCNAME(ctx, c.name)
+		if err != nil {
+			return nil, err
+		}
+		return []string{strings.TrimSuffix(cname, ".")}, nil
+	case "SRV":
+		_, srvs, err := r.LookupSRV(ctx, "", "", c.name)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]string, len(srvs))
+		for i, s := range srvs {
+			res[i] = net.JoinHostPort(strings.TrimSuffix(s.Target, "."), strconv.Itoa(int(s.Port)))
+		}
+		return res, nil
+	case "TXT":
+		return r.LookupTXT(ctx, c.name)
+	default:
+		return nil, Fatal(fmt.Errorf("unsupported record type: %s", c.rtype))
+	}
+}
+
+func (c *DNSCheck) newResolver() *net.Resolver {
+	if c.resolver == "" {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, c.resolver)
+		},
+	}
+}
+
+func (c *DNSCheck) normalize(v string) string {
+	switch c.rtype {
+	case "", "A", "AAAA":
+		if ip, err := netip.ParseAddr(v); err == nil {
+			return ip.Unmap().String()
+		}
+	case "CNAME":
+		return strings.TrimSuffix(v, ".")
+	case "SRV":
+		return strings.Replace(v, ".:", ":", 1)
+	}
+	return v
+}
+
+func (c *DNSCheck) recordType() string {
+	if c.rtype == "" {
+		return "A/AAAA"
+	}
+	return c.rtype
+}
+
+func lookupIP(ctx context.Context, r *net.Resolver, network, host string) ([]string, error) {
+	ips, err := r.LookupNetIP(ctx, network, host)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, len(ips))
+	for i, ip := range ips {
+		res[i] = ip.Unmap().String()
+	}
+	return res, nil
+}
@@ -0,0 +1,176 @@
+package healthy_test
+
+import (
+	"context"
+	"encoding/binary"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestDNSCheck_Healthy(t *testing.T) {
+	addr := startDNS(t, map[string][]dnsRecord{
+		"svc.test.": {
+			{rtype: dnsTypeA, data: []byte{10, 0, 0, 1}},
+			{rtype: dnsTypeA, data: []byte{10, 0, 0, 2}},
+		},
+		"txt.test.": {
+			{rtype: dnsTypeTXT, data: append([]byte{5}, "ready"...)},
+		},
+	})
+
+	tests := []struct {
+		name string
+		sut  *healthy.DNSCheck
+		err  string
+	}{
+		{
+			name: "should return an error if the name does not exist",
+			sut:  healthy.DNS("missing.test."),
+			err:  "missing.test. not found",
+		},
+		{
+			name: "should return an error if there are too few records",
+			sut:  healthy.DNS("svc.test.").Type("A").MinRecords(3),
+			err:  "resolved to 2 A records, expected at least 3",
+		},
+		{
+			name: "should return an error if the records do not contain the value",
+			sut:  healthy.DNS("svc.test.").Type("A").Contains("10.0.0.3"),
+			err:  "do not contain 10.0.0.3",
+		},
+		{
+			name: "should return a fatal error for unsupported types",
+			sut:  healthy.DNS("svc.test.").Type("MX"),
+			err:  "unsupported record type: MX",
+		},
+		{
+			name: "should return nil if the name resolves",
+			sut:  healthy.DNS("svc.test.").Type("a").MinRecords(2).Contains("10.0.0.1", "10.0.0.2"),
+		},
+		{
+			name: "should return nil if the txt records match",
+			sut:  healthy.DNS("txt.test.").Type("TXT").Contains("ready"),
+		},
+		{
+			name: "should not apply a timeout if the timeout is zero",
+			sut:  healthy.DNS("svc.test.").Timeout(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sut.Resolver(addr).Healthy(context.Background())
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("got %v, expected nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDNS_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		const target = "db.svc.cluster.local"
+		exp := healthy.Metadata{"type": "dns", "target": target, "record": "SRV", "resolver": "10.0.0.2:53", "timeout": "500ms"}
+		act := healthy.DNS(target).Type("srv").Resolver("10.0.0.2:53").Timeout(500 * time.Millisecond).Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+const (
+	dnsTypeA   = 1
+	dnsTypeTXT = 16
+)
+
+type dnsRecord struct {
+	rtype uint16
+	data  []byte
+}
+
+// startDNS starts a minimal udp dns server that responds to questions
+// with the matching records, or NXDOMAIN if the name is unknown.
+func startDNS(t *testing.T, records map[string][]dnsRecord) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pc.Close() })
+
+	go func() {
+		b := make([]byte, 512)
+		for {
+			n, addr, err := pc.ReadFrom(b)
+			if err != nil {
+				return
+			}
+			if res := dnsResponse(b[:n], records); res != nil {
+				pc.WriteTo(res, addr)
+			}
+		}
+	}()
+
+	return pc.LocalAddr().String()
+}
+
+func dnsResponse(req []byte, records map[string][]dnsRecord) []byte {
+	if len(req) < 12 {
+		return nil
+	}
+
+	// parse the first question name
+	var labels []string
+	i := 12
+	for i < len(req) && req[i] != 0 {
+		l := int(req[i])
+		labels = append(labels, string(req[i+1:i+1+l]))
+		i += l + 1
+	}
+	qend := i + 5 // terminator, type and class
+	if qend > len(req) {
+		return nil
+	}
+	name := strings.ToLower(strings.Join(labels, ".")) + "."
+	qtype := binary.BigEndian.Uint16(req[i+1:])
+
+	rrs, ok := records[name]
+	var answers []dnsRecord
+	for _, rr := range rrs {
+		if rr.rtype == qtype {
+			answers = append(answers, rr)
+		}
+	}
+
+	res := make([]byte, 12, 512)
+	copy(res, req[:2])
+	flags := uint16(0x8180)
+	if !ok {
+		flags |= 3 // NXDOMAIN
+	}
+	binary.BigEndian.PutUint16(res[2:], flags)
+	binary.BigEndian.PutUint16(res[4:], 1)
+	binary.BigEndian.PutUint16(res[6:], uint16(len(answers)))
+	res = append(res, req[12:qend]...)
+
+	for _, rr := range answers {
+		res = append(res, 0xc0, 12) // pointer to question name
+		res = binary.BigEndian.AppendUint16(res, rr.rtype)
+		res = binary.BigEndian.AppendUint16(res, 1)
+		res = binary.BigEndian.AppendUint32(res, 60)
+		res = binary.BigEndian.AppendUint16(res, uint16(len(rr.data)))
+		res = append(res, rr.data...)
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+package healthy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DockerCheck represents a Docker container health check.
+type DockerCheck struct {
+	http   *HTTPCheck
+	name   string
+	socket string
+}
+
+type dockerState struct {
+	Status   string `json:"Status"`
+	Running  bool   `json:"Running"`
+	ExitCode int    `json:"ExitCode"`
+	Health   *struct {
+		Status string `json:"Status"`
+		Log    []struct {
+			ExitCode int    `json:"ExitCode"`
+			Output   string `json:"Output"`
+		} `json:"Log"`
+	} `json:"Health"`
+}
+
+const (
+	dockerSocket          = "/var/run/docker.sock"
+	dockerMaxResponseSize = 4 * 1024 * 1024
+)
+
+// DockerContainer returns a Docker container health check for the supplied
+// container name or ID. The check inspects the container using the Docker
+// Engine API over the /var/run/docker.sock unix socket.
+//
+// The check returns nil once the container is running and, if the image
+// defines a HEALTHCHECK, is healthy. Exited and dead containers are returned
+// as fatal errors.
+func DockerContainer(name string) *DockerCheck {
+	return &DockerCheck{
+		http:   HTTP("http://docker").Unix(dockerSocket),
+		name:   name,
+		socket: dockerSocket,
+	}
+}
+
+// Socket specifies the Docker Engine API unix socket path.
+func (c *DockerCheck) Socket(path string) *DockerCheck {
+	c.http.Unix(path)
+	c.socket = path
+	return c
+}
+
+// Timeout specifies the HTTP client timeout.
+func (c *DockerCheck) Timeout(t time.Duration) *DockerCheck {
+	c.http.Timeout(t)
+	return c
+}
+
+// Healthy returns nil if the container is running and healthy. The
+// container status and health status are stored in the context metadata.
+func (c *DockerCheck) Healthy(ctx context.Context) error {
+	u := "http://docker/containers/" + url.PathEscape(c.name) + "/json"
+
+	return c.http.get(ctx, u, func(res *http.Response) error {
+		switch res.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			return fmt.Errorf("container %s not found", c.name)
+		default:
+			return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+		}
+
+		var body struct {
+			State dockerState `json:"State"`
+		}
+		if err := json.NewDecoder(io.LimitReader(res.Body, dockerMaxResponseSize)).Decode(&body); err != nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+
+		s := body.State
+		md := GetContextMetadata(ctx)
+		md.Set("status", s.Status)
+		if s.Health != nil {
+			md.Set("health", s.Health.Status)
+		}
+
+		return s.err()
+	})
+}
+
+// Metadata returns the check metadata.
+func (c *DockerCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    "docker",
+		"target":  c.name,
+		"socket":  c.socket,
+		"timeout": c.http.client.Timeout.String(),
+	}
+}
+
+func (s *dockerState) err() error {
+	switch s.Status {
+	case "exited":
+		return Fatal(fmt.Errorf("container exited with code %d", s.ExitCode))
+	case "dead":
+		return Fatal(errors.New("container is dead"))
+	}
+
+	// paused and restarting containers are also reported as running
+	if !s.Running || s.Status != "running" {
+		return fmt.Errorf("container is %s", s.Status)
+	}
+	if s.Health == nil {
+		return nil
+	}
+
+	switch s.Health.Status {
+	case "healthy":
+		return nil
+	case "unhealthy":
+		if n := len(s.Health.Log); n > 0 {
+			if out := strings.TrimSpace(s.Health.Log[n-1].Output); out != "" {
+				return fmt.Errorf("container is unhealthy: %s", out)
+			}
+		}
+		return errors.New("container is unhealthy")
+	default:
+		return fmt.Errorf("container health is %s", s.Health.Status)
+	}
+}
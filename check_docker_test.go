@@ -0,0 +1,160 @@
+package healthy_test
+
+import (
+	"context"
+	"maps"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestDockerCheck_Healthy(t *testing.T) {
+	containers := map[string]string{
+		"web":        `{"State":{"Status":"running","Running":true}}`,
+		"db":         `{"State":{"Status":"running","Running":true,"Health":{"Status":"healthy"}}}`,
+		"starting":   `{"State":{"Status":"running","Running":true,"Health":{"Status":"starting"}}}`,
+		"unhealthy":  `{"State":{"Status":"running","Running":true,"Health":{"Status":"unhealthy","Log":[{"ExitCode":1,"Output":"connection refused\n"}]}}}`,
+		"created":    `{"State":{"Status":"created","Running":false}}`,
+		"restarting": `{"State":{"Status":"restarting","Running":true}}`,
+		"exited":     `{"State":{"Status":"exited","Running":false,"ExitCode":137}}`,
+		"dead":       `{"State":{"Status":"dead","Running":false}}`,
+	}
+
+	path := tempSocket(t)
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name, ok := strings.CutPrefix(r.URL.Path, "/containers/")
+			if name, ok = strings.CutSuffix(name, "/json"); !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body, ok := containers[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"No such container: ` + name + `"}`))
+				return
+			}
+			w.Write([]byte(body))
+		}),
+	}
+	go s.Serve(l)
+	defer s.Close()
+
+	tests := []struct {
+		name  string
+		md    healthy.Metadata
+		err   string
+		fatal bool
+	}{
+		{
+			name: "web",
+			md:   healthy.Metadata{"status": "running"},
+		},
+		{
+			name: "db",
+			md:   healthy.Metadata{"status": "running", "health": "healthy"},
+		},
+		{
+			name: "starting",
+			md:   healthy.Metadata{"status": "running", "health": "starting"},
+			err:  "container health is starting",
+		},
+		{
+			name: "unhealthy",
+			md:   healthy.Metadata{"status": "running", "health": "unhealthy"},
+			err:  "container is unhealthy: connection refused",
+		},
+		{
+			name: "created",
+			md:   healthy.Metadata{"status": "created"},
+			err:  "container is created",
+		},
+		{
+			name: "restarting",
+			md:   healthy.Metadata{"status": "restarting"},
+			err:  "container is restarting",
+		},
+		{
+			name:  "exited",
+			md:    healthy.Metadata{"status": "exited"},
+			err:   "container exited with code 137",
+			fatal: true,
+		},
+		{
+			name:  "dead",
+			md:    healthy.Metadata{"status": "dead"},
+			err:   "container is dead",
+			fatal: true,
+		},
+		{
+			name: "unknown",
+			md:   healthy.Metadata{},
+			err:  "container unknown not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("should check the "+tt.name+" container", func(t *testing.T) {
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			err := healthy.DockerContainer(tt.name).Socket(path).Healthy(ctx)
+			if tt.err == "" && err != nil {
+				t.Errorf("got %v, expected nil", err)
+			}
+			if tt.err != "" && (err == nil || err.Error() != tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+			if !maps.Equal(md, tt.md) {
+				t.Errorf("got %v, expected %v", md, tt.md)
+			}
+		})
+	}
+
+	t.Run("should return an error if the socket does not exist", func(t *testing.T) {
+		err := healthy.DockerContainer("web").Socket(tempSocket(t)).Healthy(context.Background())
+		if err == nil || healthy.IsFatal(err) {
+			t.Errorf("got %v, expected non-fatal error", err)
+		}
+	})
+}
+
+func TestDockerContainer_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.DockerCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the default metadata",
+			sut:  healthy.DockerContainer("db"),
+			exp:  healthy.Metadata{"type": "docker", "target": "db", "socket": "/var/run/docker.sock", "timeout": "1s"},
+		},
+		{
+			name: "should return the socket metadata",
+			sut:  healthy.DockerContainer("db").Socket("/run/user/1000/docker.sock").Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "docker", "target": "db", "socket": "/run/user/1000/docker.sock", "timeout": "2s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package healthy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ElasticsearchCheck represents an Elasticsearch/OpenSearch cluster health
+// check.
+type ElasticsearchCheck struct {
+	http      *HTTPCheck
+	url       string
+	minStatus string
+	indices   []string
+	wait      time.Duration
+}
+
+const elasticsearchMaxResponseSize = 1024 * 1024
+
+// elasticsearchStatuses ranks the cluster health statuses.
+var elasticsearchStatuses = map[string]int{
+	"red":    0,
+	"yellow": 1,
+	"green":  2,
+}
+
+// Elasticsearch returns an Elasticsearch/OpenSearch cluster health check
+// for the supplied base URL, for example "http://elastic:9200". Basic
+// authentication credentials can be included in the URL.
+//
+// The check calls the cluster health API and requires a minimum status of
+// yellow by default. A yellow status is returned as a warning unless green
+// is required.
+func Elasticsearch(url string) *ElasticsearchCheck {
+	u := strings.TrimSuffix(url, "/")
+	return &ElasticsearchCheck{
+		http:      HTTP(u),
+		url:       u,
+		minStatus: "yellow",
+	}
+}
+
+// MinStatus specifies the minimum cluster status, either green or yellow.
+func (c *ElasticsearchCheck) MinStatus(status string) *ElasticsearchCheck {
+	c.minStatus = status
+	return c
+}
+
+// Indices specifies indices to request the health of, rather than the
+// whole cluster.
+func (c *ElasticsearchCheck) Indices(indices ...string) *ElasticsearchCheck {
+	c.indices = append(c.indices, indices...)
+	return c
+}
+
+// Wait specifies how long the server should wait for the minimum status
+// before responding. The duration should be less than the timeout. By
+// default the server responds immediately.
+func (c *ElasticsearchCheck) Wait(d time.Duration) *ElasticsearchCheck {
+	c.wait = d
+	return c
+}
+
+// Timeout specifies the HTTP client timeout.
+func (c *ElasticsearchCheck) Timeout(t time.Duration) *ElasticsearchCheck {
+	c.http.Timeout(t)
+	return c
+}
+
+// Healthy returns nil if the cluster status is at least the minimum status.
+// A warning is returned if the status is yellow and yellow is the minimum.
+// The cluster name and status are stored in the context metadata.
+func (c *ElasticsearchCheck) Healthy(ctx context.Context) error {
+	minRank, ok := elasticsearchStatuses[c.minStatus]
+	if !ok || minRank < 1 {
+		return Fatal(fmt.Errorf("invalid minimum status: %q", c.minStatus))
+	}
+
+	return c.http.get(ctx, c.healthURL(), func(res *http.Response) error {
+		switch res.StatusCode {
+		case http.StatusOK, http.StatusRequestTimeout:
+			// the server responds with 408 if the wait times out
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return Fatal(fmt.Errorf("incorrect status code: %d", res.StatusCode))
+		default:
+			return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+		}
+
+		var body struct {
+			ClusterName string `json:"cluster_name"`
+			Status      string `json:"status"`
+		}
+		if err := json.NewDecoder(io.LimitReader(res.Body, elasticsearchMaxResponseSize)).Decode(&body); err != nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+
+		md := GetContextMetadata(ctx)
+		md.Set("cluster_name", body.ClusterName)
+		md.Set("status", body.Status)
+
+		rank, ok := elasticsearchStatuses[body.Status]
+		switch {
+		case !ok:
+			return fmt.Errorf("unknown cluster status: %q", body.Status)
+		case rank < minRank:
+			return fmt.Errorf("cluster status is %s", body.Status)
+		case body.Status == "yellow":
+			return Warn(errors.New("cluster status is yellow"))
+		default:
+			return nil
+		}
+	})
+}
+
+// Metadata returns the check metadata.
+func (c *ElasticsearchCheck) Metadata() Metadata {
+	target := c.url
+	if u, err := url.Parse(c.url); err == nil {
+		target = u.Redacted()
+	}
+
+	md := Metadata{
+		"type":       "elasticsearch",
+		"target":     target,
+		"min_status": c.minStatus,
+		"timeout":    c.http.client.Timeout.String(),
+	}
+	if len(c.indices) > 0 {
+		md["indices"] = strings.Join(c.indices, ",")
+	}
+	if c.wait > 0 {
+		md["wait"] = c.wait.String()
+	}
+	return md
+}
+
+func (c *ElasticsearchCheck) healthURL() string {
+	u := c.url + "/_cluster/health"
+	if len(c.indices) > 0 {
+		names := make([]string, len(c.indices))
+		for i, n := range c.indices {
+			names[i] = url.PathEscape(n)
+		}
+		u += "/" + strings.Join(names, ",")
+	}
+
+	q := url.Values{}
+	q.Set("wait_for_status", c.minStatus)
+	q.Set("timeout", fmt.Sprintf("%dms", c.wait.Milliseconds()))
+
+	return u + "?" + q.Encode()
+}
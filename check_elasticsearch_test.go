@@ -0,0 +1,159 @@
+package healthy_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestElasticsearchCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   int
+		status string
+		setup  func(*healthy.ElasticsearchCheck)
+		path   string
+		query  string
+		err    string
+		warn   bool
+		fatal  bool
+	}{
+		{
+			name:   "should return nil if the cluster is green",
+			status: "green",
+			path:   "/_cluster/health",
+			query:  "timeout=0ms&wait_for_status=yellow",
+		},
+		{
+			name:   "should return a warning if the cluster is yellow",
+			status: "yellow",
+			path:   "/_cluster/health",
+			err:    "cluster status is yellow",
+			warn:   true,
+		},
+		{
+			name:   "should return an error if the cluster is red",
+			code:   http.StatusRequestTimeout,
+			status: "red",
+			path:   "/_cluster/health",
+			err:    "cluster status is red",
+		},
+		{
+			name:   "should return an error if green is required",
+			code:   http.StatusRequestTimeout,
+			status: "yellow",
+			setup:  func(c *healthy.ElasticsearchCheck) { c.MinStatus("green").Wait(500 * time.Millisecond) },
+			path:   "/_cluster/health",
+			query:  "timeout=500ms&wait_for_status=green",
+			err:    "cluster status is yellow",
+		},
+		{
+			name:   "should request the index health",
+			status: "green",
+			setup:  func(c *healthy.ElasticsearchCheck) { c.Indices("orders", "payments") },
+			path:   "/_cluster/health/orders,payments",
+		},
+		{
+			name:  "should return an error if the index does not exist",
+			code:  http.StatusNotFound,
+			setup: func(c *healthy.ElasticsearchCheck) { c.Indices("orders") },
+			path:  "/_cluster/health/orders",
+			err:   "incorrect status code: 404",
+		},
+		{
+			name:  "should return a fatal error if the request is unauthorized",
+			code:  http.StatusUnauthorized,
+			path:  "/_cluster/health",
+			err:   "incorrect status code: 401",
+			fatal: true,
+		},
+		{
+			name:  "should return a fatal error if the minimum status is invalid",
+			setup: func(c *healthy.ElasticsearchCheck) { c.MinStatus("red") },
+			err:   "invalid minimum status",
+			fatal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path, query string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path, query = r.URL.EscapedPath(), r.URL.RawQuery
+				if tt.code != 0 {
+					w.WriteHeader(tt.code)
+				}
+				fmt.Fprintf(w, `{"cluster_name":"docker-cluster","status":%q,"timed_out":false}`, tt.status)
+			}))
+			defer srv.Close()
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			c := healthy.Elasticsearch(srv.URL + "/")
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+
+			err := c.Healthy(ctx)
+			if tt.err == "" && err != nil {
+				t.Fatalf("got %v, expected nil", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsWarn(err); act != tt.warn {
+				t.Errorf("got warn %t, expected %t", act, tt.warn)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+			if path != tt.path {
+				t.Errorf("got %s, expected %s", path, tt.path)
+			}
+			if tt.query != "" && query != tt.query {
+				t.Errorf("got %s, expected %s", query, tt.query)
+			}
+			if tt.status != "" && tt.code != http.StatusNotFound {
+				if act := md.Get("status"); act != tt.status {
+					t.Errorf("got %v, expected %s", act, tt.status)
+				}
+			}
+		})
+	}
+}
+
+func TestElasticsearch_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.ElasticsearchCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the default metadata",
+			sut:  healthy.Elasticsearch("http://elastic:secret@es:9200/"),
+			exp:  healthy.Metadata{"type": "elasticsearch", "target": "http://elastic:xxxxx@es:9200", "min_status": "yellow", "timeout": "1s"},
+		},
+		{
+			name: "should return the index metadata",
+			sut:  healthy.Elasticsearch("http://es:9200").MinStatus("green").Indices("orders").Wait(time.Second).Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "elasticsearch", "target": "http://es:9200", "min_status": "green", "indices": "orders", "wait": "1s", "timeout": "2s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
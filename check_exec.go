@@ -0,0 +1,119 @@
+package healthy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ExecCheck represents a command execution health check.
+type ExecCheck struct {
+	name     string
+	args     []string
+	codes    []int
+	contains []byte
+	matches  *regexp.Regexp
+	timeout  time.Duration
+}
+
+// Exec returns a command execution health check.
+// By default the check returns nil if the command exits with code zero.
+func Exec(name string, args ...string) *ExecCheck {
+	return &ExecCheck{
+		name:    name,
+		args:    args,
+		codes:   []int{0},
+		timeout: 10 * time.Second,
+	}
+}
+
+// ExitCodes specifies the exit codes that indicate success.
+func (c *ExecCheck) ExitCodes(codes ...int) *ExecCheck {
+	c.codes = codes
+	return c
+}
+
+// Contains specifies content that stdout must contain.
+func (c *ExecCheck) Contains(s string) *ExecCheck {
+	c.contains = []byte(s)
+	return c
+}
+
+// Matches specifies a regular expression that stdout must match.
+func (c *ExecCheck) Matches(re *regexp.Regexp) *ExecCheck {
+	c.matches = re
+	return c
+}
+
+// Timeout specifies the command timeout. A timeout of zero means no timeout.
+// The process group is killed if the timeout elapses.
+func (c *ExecCheck) Timeout(t time.Duration) *ExecCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the command exits with an expected code and the
+// output satisfies the assertions. A fatal error is returned if the
+// command cannot be found.
+func (c *ExecCheck) Healthy(ctx context.Context) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.WaitDelay = time.Second
+	setProcessGroup(cmd)
+
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		return Fatal(err)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+
+	code := 0
+	var ee *exec.ExitError
+	switch {
+	case errors.As(err, &ee):
+		code = ee.ExitCode()
+	case err != nil:
+		return err
+	}
+
+	if !slices.Contains(c.codes, code) {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("incorrect exit code: %d: %s", code, msg)
+		}
+		return fmt.Errorf("incorrect exit code: %d", code)
+	}
+
+	if c.contains != nil && !bytes.Contains(stdout.Bytes(), c.contains) {
+		return fmt.Errorf("output does not contain %q", c.contains)
+	}
+	if c.matches != nil && !c.matches.Match(stdout.Bytes()) {
+		return fmt.Errorf("output does not match %s", c.matches)
+	}
+
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *ExecCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    "exec",
+		"target":  strings.Join(append([]string{c.name}, c.args...), " "),
+		"timeout": c.timeout.String(),
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package healthy
+
+import "os/exec"
+
+// setProcessGroup is not supported on this platform, so only the command
+// process is killed on cancellation.
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,111 @@
+package healthy_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestExecCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name  string
+		sut   *healthy.ExecCheck
+		err   string
+		fatal bool
+	}{
+		{
+			name:  "should return a fatal error if the command does not exist",
+			sut:   healthy.Exec("healthy-missing-command"),
+			err:   "not found",
+			fatal: true,
+		},
+		{
+			name: "should return an error on unexpected exit code",
+			sut:  healthy.Exec("sh", "-c", "echo 'no response' >&2; exit 2"),
+			err:  "incorrect exit code: 2: no response",
+		},
+		{
+			name: "should return nil on zero exit code",
+			sut:  healthy.Exec("sh", "-c", "exit 0"),
+		},
+		{
+			name: "should return nil on expected exit code",
+			sut:  healthy.Exec("sh", "-c", "exit 3").ExitCodes(0, 3),
+		},
+		{
+			name: "should return an error if the output does not contain the value",
+			sut:  healthy.Exec("echo", "accepting connections").Contains("rejecting"),
+			err:  "output does not contain",
+		},
+		{
+			name: "should return nil if the output contains the value",
+			sut:  healthy.Exec("echo", "accepting connections").Contains("accepting"),
+		},
+		{
+			name: "should return an error if the output does not match",
+			sut:  healthy.Exec("echo", "topic-a").Matches(regexp.MustCompile(`(?m)^topic-b$`)),
+			err:  "output does not match",
+		},
+		{
+			name: "should return nil if the output matches",
+			sut:  healthy.Exec("echo", "topic-a").Matches(regexp.MustCompile(`(?m)^topic-a$`)),
+		},
+		{
+			name: "should return an error on timeout",
+			sut:  healthy.Exec("sleep", "10").Timeout(10 * time.Millisecond),
+			err:  context.DeadlineExceeded.Error(),
+		},
+		{
+			name: "should not apply a timeout if the timeout is zero",
+			sut:  healthy.Exec("true").Timeout(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sut.Healthy(context.Background())
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("got %v, expected nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+		})
+	}
+
+	t.Run("should kill the process group on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := healthy.Exec("sh", "-c", "sleep 10 & sleep 10; wait").Healthy(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+		}
+		if act := time.Since(start); act > 500*time.Millisecond {
+			t.Errorf("got %v, expected the process group to be killed", act)
+		}
+	})
+}
+
+func TestExec_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		exp := healthy.Metadata{"type": "exec", "target": "pg_isready -h db", "timeout": "2s"}
+		act := healthy.Exec("pg_isready", "-h", "db").Timeout(2 * time.Second).Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
@@ -1,15 +1,160 @@
 package healthy
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"regexp"
+	"strconv"
+	"time"
 )
 
+// FileCheck represents a file health check.
+type FileCheck struct {
+	path           string
+	contains       []byte
+	matches        *regexp.Regexp
+	minSize        int64
+	modifiedWithin time.Duration
+	mode           *fs.FileMode
+	isDir          bool
+	notExists      bool
+}
+
 // File returns a file health check.
-// The check returns nil if the file exists.
-func File(path string) MetadataCheck {
-	return WithMetadata(func(ctx context.Context) error {
-		_, err := os.Stat(path)
+// By default the check returns nil if the file exists.
+func File(path string) *FileCheck {
+	return &FileCheck{
+		path:    path,
+		minSize: -1,
+	}
+}
+
+// Contains specifies content that the file must contain.
+func (c *FileCheck) Contains(s string) *FileCheck {
+	c.contains = []byte(s)
+	return c
+}
+
+// Matches specifies a regular expression that the file content must match.
+func (c *FileCheck) Matches(re *regexp.Regexp) *FileCheck {
+	c.matches = re
+	return c
+}
+
+// MinSize specifies the minimum file size in bytes.
+func (c *FileCheck) MinSize(n int64) *FileCheck {
+	c.minSize = n
+	return c
+}
+
+// ModifiedWithin specifies that the file must have been modified within
+// the duration, for example to check a heartbeat file.
+func (c *FileCheck) ModifiedWithin(d time.Duration) *FileCheck {
+	c.modifiedWithin = d
+	return c
+}
+
+// Mode specifies the expected permission bits.
+func (c *FileCheck) Mode(m fs.FileMode) *FileCheck {
+	m = m.Perm()
+	c.mode = &m
+	return c
+}
+
+// IsDir specifies that the path must be a directory.
+func (c *FileCheck) IsDir() *FileCheck {
+	c.isDir = true
+	return c
+}
+
+// NotExists specifies that the path must not exist, for example to wait
+// for a lock file to be removed. Other assertions are ignored.
+func (c *FileCheck) NotExists() *FileCheck {
+	c.notExists = true
+	return c
+}
+
+// Healthy returns nil if the file exists and satisfies the assertions.
+func (c *FileCheck) Healthy(ctx context.Context) error {
+	fi, err := os.Stat(c.path)
+	if c.notExists {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil
+		case err != nil:
+			return err
+		default:
+			return fmt.Errorf("%s exists", c.path)
+		}
+	}
+	if err != nil {
 		return err
-	}, "type", "file", "target", path)
+	}
+
+	if c.isDir && !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", c.path)
+	}
+	if c.mode != nil && fi.Mode().Perm() != *c.mode {
+		return fmt.Errorf("incorrect mode: %s", fi.Mode().Perm())
+	}
+	if c.minSize >= 0 && fi.Size() < c.minSize {
+		return fmt.Errorf("incorrect size: %d", fi.Size())
+	}
+	if c.modifiedWithin > 0 && time.Since(fi.ModTime()) > c.modifiedWithin {
+		return fmt.Errorf("not modified since %s", fi.ModTime().Format(time.RFC3339))
+	}
+
+	if c.contains == nil && c.matches == nil {
+		return nil
+	}
+
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	if c.contains != nil && !bytes.Contains(b, c.contains) {
+		return fmt.Errorf("content does not contain %q", c.contains)
+	}
+	if c.matches != nil && !c.matches.Match(b) {
+		return fmt.Errorf("content does not match %s", c.matches)
+	}
+
+	return nil
+}
+
+// Metadata returns the check metadata, including any active assertions.
+func (c *FileCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":   "file",
+		"target": c.path,
+	}
+
+	if c.notExists {
+		md["not_exists"] = "true"
+		return md
+	}
+	if c.contains != nil {
+		md["contains"] = string(c.contains)
+	}
+	if c.matches != nil {
+		md["matches"] = c.matches.String()
+	}
+	if c.minSize >= 0 {
+		md["min_size"] = strconv.FormatInt(c.minSize, 10)
+	}
+	if c.modifiedWithin > 0 {
+		md["modified_within"] = c.modifiedWithin.String()
+	}
+	if c.mode != nil {
+		md["mode"] = c.mode.String()
+	}
+	if c.isDir {
+		md["dir"] = "true"
+	}
+
+	return md
 }
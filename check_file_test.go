@@ -5,8 +5,11 @@ import (
 	"maps"
 	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stevecallear/healthy"
 )
@@ -44,6 +47,103 @@ func TestFile_Check(t *testing.T) {
 	})
 }
 
+func TestFileCheck_Assertions(t *testing.T) {
+	dir := t.TempDir()
+
+	fn := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(fn, []byte("ready: true\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	old := filepath.Join(dir, "heartbeat")
+	if err := os.WriteFile(old, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mt := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(old, mt, mt); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		sut  *healthy.FileCheck
+		err  bool
+	}{
+		{
+			name: "should return an error if the content does not contain the value",
+			sut:  healthy.File(fn).Contains("ready: false"),
+			err:  true,
+		},
+		{
+			name: "should return nil if the content contains the value",
+			sut:  healthy.File(fn).Contains("ready: true"),
+		},
+		{
+			name: "should return an error if the content does not match",
+			sut:  healthy.File(fn).Matches(regexp.MustCompile(`(?m)^port: \d+$`)),
+			err:  true,
+		},
+		{
+			name: "should return nil if the content matches",
+			sut:  healthy.File(fn).Matches(regexp.MustCompile(`(?m)^ready: (true|yes)$`)),
+		},
+		{
+			name: "should return an error if the file is too small",
+			sut:  healthy.File(fn).MinSize(100),
+			err:  true,
+		},
+		{
+			name: "should return nil if the file is large enough",
+			sut:  healthy.File(fn).MinSize(12),
+		},
+		{
+			name: "should return an error if the file has not been modified",
+			sut:  healthy.File(old).ModifiedWithin(time.Minute),
+			err:  true,
+		},
+		{
+			name: "should return nil if the file has been modified",
+			sut:  healthy.File(fn).ModifiedWithin(time.Minute),
+		},
+		{
+			name: "should return an error if the mode is incorrect",
+			sut:  healthy.File(old).Mode(0o640),
+			err:  true,
+		},
+		{
+			name: "should return nil if the mode is correct",
+			sut:  healthy.File(fn).Mode(0o640),
+		},
+		{
+			name: "should return an error if the path is not a directory",
+			sut:  healthy.File(fn).IsDir(),
+			err:  true,
+		},
+		{
+			name: "should return nil if the path is a directory",
+			sut:  healthy.File(dir).IsDir(),
+		},
+		{
+			name: "should return an error if the file exists",
+			sut:  healthy.File(fn).NotExists(),
+			err:  true,
+		},
+		{
+			name: "should return nil if the file does not exist",
+			sut:  healthy.File(filepath.Join(dir, "lock")).NotExists(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sut.Healthy(context.Background())
+			if (err != nil) != tt.err {
+				t.Errorf("got %v, expected error %t", err, tt.err)
+			}
+		})
+	}
+}
+
 func TestFile_Metadata(t *testing.T) {
 	t.Run("should return the check metadata", func(t *testing.T) {
 		const file = "test.txt"
@@ -53,6 +153,40 @@ func TestFile_Metadata(t *testing.T) {
 			t.Errorf("got %v, expected %v", act, exp)
 		}
 	})
+
+	t.Run("should return the assertion metadata", func(t *testing.T) {
+		const file = "test"
+		exp := healthy.Metadata{
+			"type":            "file",
+			"target":          file,
+			"contains":        "a",
+			"matches":         "^b$",
+			"min_size":        "10",
+			"modified_within": "1m0s",
+			"mode":            "-rw-r-----",
+			"dir":             "true",
+		}
+		act := healthy.File(file).
+			Contains("a").
+			Matches(regexp.MustCompile("^b$")).
+			MinSize(10).
+			ModifiedWithin(time.Minute).
+			Mode(0o640).
+			IsDir().
+			Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+
+	t.Run("should return the not exists metadata", func(t *testing.T) {
+		const file = "test.lock"
+		exp := healthy.Metadata{"type": "file", "target": file, "not_exists": "true"}
+		act := healthy.File(file).NotExists().Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
 }
 
 func tempFile() (string, func()) {
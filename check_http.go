@@ -3,7 +3,9 @@ package healthy
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -12,6 +14,7 @@ type HTTPCheck struct {
 	client     *http.Client
 	url        string
 	statusCode int
+	socket     string
 }
 
 // HTTP returns an HTTP health check.
@@ -35,9 +38,52 @@ func (c *HTTPCheck) Expect(statusCode int) *HTTPCheck {
 	return c
 }
 
+// Unix specifies a unix domain socket to connect to instead of the URL host,
+// for example the Docker daemon at /var/run/docker.sock.
+func (c *HTTPCheck) Unix(path string) *HTTPCheck {
+	c.socket = path
+	c.client.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}
+	return c
+}
+
 // Healthy returns true if the target URL returns the expected status code.
+// The request is cancelled if the context is done.
 func (c *HTTPCheck) Healthy(ctx context.Context) error {
-	req, err := http.NewRequest(http.MethodGet, c.url, nil)
+	return c.get(ctx, c.url, func(res *http.Response) error {
+		if res.StatusCode != c.statusCode {
+			return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+		}
+		return nil
+	})
+}
+
+// Metadata returns the check metadata.
+func (c *HTTPCheck) Metadata() Metadata {
+	target := c.url
+	if u, err := url.Parse(c.url); err == nil {
+		target = u.Redacted()
+	}
+
+	md := Metadata{
+		"type":    "http",
+		"target":  target,
+		"timeout": c.client.Timeout.String(),
+	}
+	if c.socket != "" {
+		md["socket"] = c.socket
+	}
+	return md
+}
+
+// get sends a GET request to the URL using the check client and passes the
+// response to fn. The response body is closed once fn returns.
+func (c *HTTPCheck) get(ctx context.Context, url string, fn func(*http.Response) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return Fatal(err)
 	}
@@ -46,19 +92,7 @@ func (c *HTTPCheck) Healthy(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
-	if res.StatusCode != c.statusCode {
-		return fmt.Errorf("incorrect status code: %d", res.StatusCode)
-	}
-
-	return nil
-}
-
-// Metadata returns the check metadata.
-func (c *HTTPCheck) Metadata() Metadata {
-	return Metadata{
-		"type":    "http",
-		"target":  c.url,
-		"timeout": c.client.Timeout.String(),
-	}
+	return fn(res)
 }
@@ -2,10 +2,12 @@ package healthy_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -53,6 +55,49 @@ func TestHTTPCheck_Healthy(t *testing.T) {
 			t.Errorf("got %v, expected error", err)
 		}
 	})
+
+	t.Run("should return an error on context cancellation", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		sut := healthy.HTTP(srv.URL).Timeout(time.Minute)
+		err := sut.Healthy(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestHTTPCheck_Unix(t *testing.T) {
+	path := tempSocket(t)
+
+	t.Run("should connect to the socket", func(t *testing.T) {
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/_ping" {
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}),
+		}
+		go s.Serve(l)
+		defer s.Close()
+
+		sut := healthy.HTTP("http://docker/_ping").Unix(path)
+		err = sut.Healthy(context.Background())
+		if err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
 }
 
 func TestHTTP_Metadata(t *testing.T) {
@@ -64,6 +109,15 @@ func TestHTTP_Metadata(t *testing.T) {
 			t.Errorf("got %v, expected %v", act, exp)
 		}
 	})
+
+	t.Run("should return the socket metadata", func(t *testing.T) {
+		const target = "http://docker/_ping"
+		exp := healthy.Metadata{"type": "http", "target": target, "timeout": "1s", "socket": "/var/run/docker.sock"}
+		act := healthy.HTTP(target).Unix("/var/run/docker.sock").Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
 }
 
 func startHTTPDelayed(addr string, delay time.Duration) func() error {
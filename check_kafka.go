@@ -0,0 +1,339 @@
+package healthy
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// KafkaCheck represents a Kafka broker health check.
+type KafkaCheck struct {
+	brokers []string
+	topics  []string
+	timeout time.Duration
+}
+
+const (
+	kafkaAPIMetadata    = 3
+	kafkaAPIVersions    = 18
+	kafkaMetadataV4     = 4
+	kafkaClientID       = "healthy"
+	kafkaMaxMessageSize = 64 * 1024 * 1024
+)
+
+// Kafka returns a Kafka broker health check.
+// The check issues ApiVersions and Metadata requests and returns nil once
+// a broker responds. Brokers are tried in order.
+func Kafka(brokers ...string) *KafkaCheck {
+	return &KafkaCheck{
+		brokers: brokers,
+		timeout: time.Second,
+	}
+}
+
+// Topics specifies topics that must exist with a leader for every partition.
+func (c *KafkaCheck) Topics(topics ...string) *KafkaCheck {
+	c.topics = append(c.topics, topics...)
+	return c
+}
+
+// Timeout specifies the timeout for each broker.
+func (c *KafkaCheck) Timeout(t time.Duration) *KafkaCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if a broker responds to the metadata request and
+// all topics exist with a leader for every partition. The cluster ID is
+// stored in the context metadata.
+func (c *KafkaCheck) Healthy(ctx context.Context) error {
+	if len(c.brokers) < 1 {
+		return Fatal(errors.New("no brokers specified"))
+	}
+
+	var errs []error
+	for _, b := range c.brokers {
+		md, err := c.metadata(ctx, b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", b, err))
+			continue
+		}
+
+		if md.clusterID != "" {
+			GetContextMetadata(ctx).Set("cluster_id", md.clusterID)
+		}
+		return md.validate(c.topics)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Metadata returns the check metadata.
+func (c *KafkaCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "kafka",
+		"target":  strings.Join(c.brokers, ","),
+		"timeout": c.timeout.String(),
+	}
+	if len(c.topics) > 0 {
+		md["topics"] = strings.Join(c.topics, ",")
+	}
+	return md
+}
+
+func (c *KafkaCheck) metadata(ctx context.Context, broker string) (*kafkaMetadata, error) {
+	conn, err := dialDeadline(ctx, "tcp", broker, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	kc := &kafkaConn{rw: conn}
+
+	res, err := kc.request(kafkaAPIVersions, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkKafkaAPIVersions(res); err != nil {
+		return nil, err
+	}
+
+	req := binary.BigEndian.AppendUint32(nil, uint32(len(c.topics)))
+	for _, t := range c.topics {
+		req = appendKafkaString(req, t)
+	}
+	req = append(req, 0) // allow_auto_topic_creation
+
+	if res, err = kc.request(kafkaAPIMetadata, kafkaMetadataV4, req); err != nil {
+		return nil, err
+	}
+
+	return parseKafkaMetadata(res)
+}
+
+type kafkaMetadata struct {
+	clusterID string
+	brokers   int
+	topics    map[string]kafkaTopic
+}
+
+type kafkaTopic struct {
+	errorCode  int16
+	partitions int
+	leaderless []int32
+}
+
+func (m *kafkaMetadata) validate(topics []string) error {
+	if m.brokers < 1 {
+		return errors.New("no brokers available")
+	}
+
+	var errs []error
+	for _, name := range topics {
+		t, ok := m.topics[name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("topic %s not found", name))
+		case t.errorCode != 0:
+			errs = append(errs, fmt.Errorf("topic %s: %s", name, kafkaErrorMessage(t.errorCode)))
+		case t.partitions < 1:
+			errs = append(errs, fmt.Errorf("topic %s has no partitions", name))
+		case len(t.leaderless) > 0:
+			errs = append(errs, fmt.Errorf("topic %s partitions %v have no leader", name, t.leaderless))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkKafkaAPIVersions(b []byte) error {
+	r := &kafkaReader{b: b}
+
+	if code := r.int16(); code != 0 {
+		return fmt.Errorf("api versions: %s", kafkaErrorMessage(code))
+	}
+
+	for range r.int32() {
+		key, min, max := r.int16(), r.int16(), r.int16()
+		if r.err != nil {
+			return r.err
+		}
+		if key == kafkaAPIMetadata {
+			if min > kafkaMetadataV4 || max < kafkaMetadataV4 {
+				return Fatal(fmt.Errorf("unsupported metadata versions: %d-%d", min, max))
+			}
+			return r.err
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	return Fatal(errors.New("metadata api not supported"))
+}
+
+func parseKafkaMetadata(b []byte) (*kafkaMetadata, error) {
+	r := &kafkaReader{b: b}
+	m := &kafkaMetadata{topics: map[string]kafkaTopic{}}
+
+	r.int32() // throttle_time_ms
+
+	n := r.int32()
+	for range n {
+		if r.err != nil {
+			return nil, r.err
+		}
+		r.int32()  // node_id
+		r.string() // host
+		r.int32()  // port
+		r.string() // rack
+	}
+	m.brokers = int(n)
+
+	m.clusterID = r.string()
+	r.int32() // controller_id
+
+	for range r.int32() {
+		if r.err != nil {
+			return nil, r.err
+		}
+		var t kafkaTopic
+		t.errorCode = r.int16()
+		name := r.string()
+		r.bool() // is_internal
+
+		np := r.int32()
+		for range np {
+			if r.err != nil {
+				return nil, r.err
+			}
+			r.int16() // error_code
+			index := r.int32()
+			if leader := r.int32(); leader < 0 {
+				t.leaderless = append(t.leaderless, index)
+			}
+			r.skip(4 * int(r.int32())) // replica_nodes
+			r.skip(4 * int(r.int32())) // isr_nodes
+		}
+		t.partitions = int(np)
+
+		m.topics[name] = t
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return m, nil
+}
+
+func kafkaErrorMessage(code int16) string {
+	switch code {
+	case 3:
+		return "unknown topic or partition"
+	case 5:
+		return "leader not available"
+	case 35:
+		return "unsupported version"
+	default:
+		return fmt.Sprintf("error code %d", code)
+	}
+}
+
+func appendKafkaString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+type kafkaConn struct {
+	rw  io.ReadWriter
+	cid int32
+}
+
+func (c *kafkaConn) request(key, version int16, body []byte) ([]byte, error) {
+	c.cid++
+
+	h := binary.BigEndian.AppendUint16(make([]byte, 4), uint16(key))
+	h = binary.BigEndian.AppendUint16(h, uint16(version))
+	h = binary.BigEndian.AppendUint32(h, uint32(c.cid))
+	h = appendKafkaString(h, kafkaClientID)
+
+	msg := append(h, body...)
+	binary.BigEndian.PutUint32(msg, uint32(len(msg)-4))
+
+	if _, err := c.rw.Write(msg); err != nil {
+		return nil, err
+	}
+
+	var size [4]byte
+	if _, err := io.ReadFull(c.rw, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n < 4 || n > kafkaMaxMessageSize {
+		return nil, errors.New("invalid message size")
+	}
+
+	res := make([]byte, n)
+	if _, err := io.ReadFull(c.rw, res); err != nil {
+		return nil, err
+	}
+	if cid := int32(binary.BigEndian.Uint32(res)); cid != c.cid {
+		return nil, fmt.Errorf("unexpected correlation id: %d", cid)
+	}
+
+	return res[4:], nil
+}
+
+type kafkaReader struct {
+	b   []byte
+	err error
+}
+
+func (r *kafkaReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.b) {
+		r.err = errors.New("invalid response")
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *kafkaReader) skip(n int) {
+	r.next(n)
+}
+
+func (r *kafkaReader) bool() bool {
+	b := r.next(1)
+	return b != nil && b[0] != 0
+}
+
+func (r *kafkaReader) int16() int16 {
+	if b := r.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int32() int32 {
+	if b := r.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+// string reads a nullable string, returning an empty string for null.
+func (r *kafkaReader) string() string {
+	n := r.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(r.next(int(n)))
+}
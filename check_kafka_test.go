@@ -0,0 +1,177 @@
+package healthy_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestKafkaCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		server fakeKafka
+		topics []string
+		err    string
+		fatal  bool
+	}{
+		{
+			name:   "should return nil if the broker responds",
+			server: fakeKafka{},
+		},
+		{
+			name: "should return nil if the topics exist with leaders",
+			server: fakeKafka{topics: []fakeKafkaTopic{
+				{name: "orders", leaders: []int32{1, 1, 2}},
+				{name: "payments", leaders: []int32{2}},
+			}},
+			topics: []string{"orders", "payments"},
+		},
+		{
+			name:   "should return an error if a topic does not exist",
+			server: fakeKafka{topics: []fakeKafkaTopic{{name: "orders", errorCode: 3}}},
+			topics: []string{"orders"},
+			err:    "topic orders: unknown topic or partition",
+		},
+		{
+			name:   "should return an error if a topic is not returned",
+			server: fakeKafka{},
+			topics: []string{"orders"},
+			err:    "topic orders not found",
+		},
+		{
+			name:   "should return an error if a partition has no leader",
+			server: fakeKafka{topics: []fakeKafkaTopic{{name: "orders", leaders: []int32{1, -1}}}},
+			topics: []string{"orders"},
+			err:    "topic orders partitions [1] have no leader",
+		},
+		{
+			name:   "should return a fatal error if the metadata version is not supported",
+			server: fakeKafka{maxMetadata: 2},
+			err:    "unsupported metadata versions",
+			fatal:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTCPServer(t, tt.server.serve)
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			err := healthy.Kafka("localhost:1", addr).Topics(tt.topics...).Timeout(100 * time.Millisecond).Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				if act, exp := md.Get("cluster_id"), "cluster"; act != exp {
+					t.Errorf("got %v, expected %s", act, exp)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+		})
+	}
+
+	t.Run("should return a fatal error if there are no brokers", func(t *testing.T) {
+		err := healthy.Kafka().Healthy(context.Background())
+		if !healthy.IsFatal(err) {
+			t.Errorf("got %v, expected fatal error", err)
+		}
+	})
+}
+
+func TestKafka_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		exp := healthy.Metadata{"type": "kafka", "target": "k1:9092,k2:9092", "topics": "orders", "timeout": "2s"}
+		act := healthy.Kafka("k1:9092", "k2:9092").Topics("orders").Timeout(2 * time.Second).Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+type fakeKafkaTopic struct {
+	name      string
+	errorCode int16
+	leaders   []int32
+}
+
+// fakeKafka is a minimal broker supporting ApiVersions and Metadata v4.
+type fakeKafka struct {
+	topics      []fakeKafkaTopic
+	maxMetadata int16
+}
+
+func (s fakeKafka) serve(conn net.Conn) {
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+
+		key := binary.BigEndian.Uint16(req)
+		res := append([]byte{}, req[4:8]...) // correlation id
+
+		switch key {
+		case 18:
+			maxMetadata := s.maxMetadata
+			if maxMetadata == 0 {
+				maxMetadata = 12
+			}
+			res = binary.BigEndian.AppendUint16(res, 0)
+			res = binary.BigEndian.AppendUint32(res, 2)
+			res = append(res, 0, 3, 0, 0, byte(maxMetadata>>8), byte(maxMetadata))
+			res = append(res, 0, 18, 0, 0, 0, 3)
+		case 3:
+			res = binary.BigEndian.AppendUint32(res, 0) // throttle
+			res = binary.BigEndian.AppendUint32(res, 1) // brokers
+			res = binary.BigEndian.AppendUint32(res, 1)
+			res = kafkaStr(res, "localhost")
+			res = binary.BigEndian.AppendUint32(res, 9092)
+			res = binary.BigEndian.AppendUint16(res, 0xffff) // null rack
+			res = kafkaStr(res, "cluster")
+			res = binary.BigEndian.AppendUint32(res, 1) // controller
+			res = binary.BigEndian.AppendUint32(res, uint32(len(s.topics)))
+			for _, t := range s.topics {
+				res = binary.BigEndian.AppendUint16(res, uint16(t.errorCode))
+				res = kafkaStr(res, t.name)
+				res = append(res, 0)
+				res = binary.BigEndian.AppendUint32(res, uint32(len(t.leaders)))
+				for i, l := range t.leaders {
+					res = binary.BigEndian.AppendUint16(res, 0)
+					res = binary.BigEndian.AppendUint32(res, uint32(i))
+					res = binary.BigEndian.AppendUint32(res, uint32(l))
+					res = binary.BigEndian.AppendUint32(res, 1) // replicas
+					res = binary.BigEndian.AppendUint32(res, 1)
+					res = binary.BigEndian.AppendUint32(res, 0) // isr
+				}
+			}
+		default:
+			return
+		}
+
+		conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(res))), res...))
+	}
+}
+
+func kafkaStr(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
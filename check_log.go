@@ -0,0 +1,147 @@
+package healthy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// LogCheck represents a log line health check.
+type LogCheck struct {
+	path    string
+	re      *regexp.Regexp
+	mu      sync.Mutex
+	start   int64
+	started bool
+	offset  int64
+	file    os.FileInfo
+	head    []byte
+	matched bool
+}
+
+const (
+	offsetEnd = -1
+	headSize  = 256
+)
+
+// LogLine returns a log line health check.
+// The check tails the file across attempts and returns nil once a line
+// matching the regular expression has been written. Truncation and
+// rotation are detected and the new file is read from the start.
+func LogLine(path string, re *regexp.Regexp) *LogCheck {
+	return &LogCheck{
+		path: path,
+		re:   re,
+	}
+}
+
+// Offset specifies the byte offset to start reading the file from. If the
+// file is shorter than the offset it is read from the end. The default
+// value is zero.
+func (c *LogCheck) Offset(n int64) *LogCheck {
+	c.start = n
+	return c
+}
+
+// FromEnd specifies that only lines written after the first attempt
+// should be matched. If the file does not exist on the first attempt then
+// it is read from the start once created.
+func (c *LogCheck) FromEnd() *LogCheck {
+	c.start = offsetEnd
+	return c
+}
+
+// Healthy returns nil once a matching line has been written.
+func (c *LogCheck) Healthy(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.matched {
+		return nil
+	}
+
+	first := !c.started
+	c.started = true
+
+	f, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	head := make([]byte, headSize)
+	n, err := f.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	head = head[:n]
+
+	switch {
+	case c.file == nil:
+		c.offset = c.start
+		if c.start == offsetEnd {
+			c.offset = 0
+			if first {
+				c.offset = fi.Size()
+			}
+		}
+		// an offset past the end is not treated as truncation
+		c.offset = min(c.offset, fi.Size())
+	case !os.SameFile(c.file, fi), fi.Size() < c.offset, !sharePrefix(c.head, head):
+		c.offset = 0 // rotated, truncated or rewritten
+	}
+	c.file = fi
+	c.head = head
+
+	if _, err = f.Seek(c.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	// only consume complete lines
+	end := bytes.LastIndexByte(b, '\n') + 1
+	for line := range bytes.Lines(b[:end]) {
+		if c.re.Match(bytes.TrimRight(line, "\r\n")) {
+			c.matched = true
+			return nil
+		}
+	}
+	c.offset += int64(end)
+
+	return fmt.Errorf("no line matching %s", c.re)
+}
+
+// Metadata returns the check metadata.
+func (c *LogCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "log",
+		"target":  c.path,
+		"pattern": c.re.String(),
+	}
+	switch {
+	case c.start == offsetEnd:
+		md["offset"] = "end"
+	case c.start > 0:
+		md["offset"] = strconv.FormatInt(c.start, 10)
+	}
+	return md
+}
+
+func sharePrefix(a, b []byte) bool {
+	n := min(len(a), len(b))
+	return bytes.Equal(a[:n], b[:n])
+}
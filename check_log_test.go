@@ -0,0 +1,168 @@
+package healthy_test
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestLogCheck_Healthy(t *testing.T) {
+	ready := regexp.MustCompile(`ready to accept connections$`)
+
+	t.Run("should return an error if the file does not exist", func(t *testing.T) {
+		sut := healthy.LogLine(filepath.Join(t.TempDir(), "missing.log"), ready)
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+	})
+
+	t.Run("should return nil once the line is written", func(t *testing.T) {
+		fn := filepath.Join(t.TempDir(), "app.log")
+		writeLog(t, fn, "starting\nready to accept")
+
+		sut := healthy.LogLine(fn, ready)
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		appendLog(t, fn, " connections")
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error for incomplete line")
+		}
+
+		appendLog(t, fn, "\n")
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+
+		// matches are retained
+		writeLog(t, fn, "")
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should ignore existing lines from the end", func(t *testing.T) {
+		fn := filepath.Join(t.TempDir(), "app.log")
+		writeLog(t, fn, "ready to accept connections\n")
+
+		sut := healthy.LogLine(fn, ready).FromEnd()
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		appendLog(t, fn, "ready to accept connections\n")
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should read files created after the first attempt from the start", func(t *testing.T) {
+		fn := filepath.Join(t.TempDir(), "app.log")
+
+		sut := healthy.LogLine(fn, ready).FromEnd()
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		writeLog(t, fn, "ready to accept connections\n")
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should start from the offset", func(t *testing.T) {
+		fn := filepath.Join(t.TempDir(), "app.log")
+		writeLog(t, fn, "ready to accept connections\nother\n")
+
+		sut := healthy.LogLine(fn, ready).Offset(28)
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+	})
+
+	t.Run("should not read before an offset past the end", func(t *testing.T) {
+		fn := filepath.Join(t.TempDir(), "app.log")
+		writeLog(t, fn, "ready to accept connections\n")
+
+		sut := healthy.LogLine(fn, ready).Offset(100)
+		for range 2 {
+			if err := sut.Healthy(context.Background()); err == nil {
+				t.Error("got nil, expected error")
+			}
+		}
+
+		appendLog(t, fn, "ready to accept connections\n")
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should handle truncation", func(t *testing.T) {
+		fn := filepath.Join(t.TempDir(), "app.log")
+		writeLog(t, fn, "starting\nstill starting\n")
+
+		sut := healthy.LogLine(fn, ready)
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		writeLog(t, fn, "ready to accept connections\n")
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should handle rotation", func(t *testing.T) {
+		dir := t.TempDir()
+		fn := filepath.Join(dir, "app.log")
+		writeLog(t, fn, "starting\n")
+
+		sut := healthy.LogLine(fn, ready)
+		if err := sut.Healthy(context.Background()); err == nil {
+			t.Error("got nil, expected error")
+		}
+
+		if err := os.Rename(fn, filepath.Join(dir, "app.log.1")); err != nil {
+			t.Fatal(err)
+		}
+		writeLog(t, fn, "ready to accept connections\n")
+
+		if err := sut.Healthy(context.Background()); err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+}
+
+func TestLogLine_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		exp := healthy.Metadata{"type": "log", "target": "app.log", "pattern": "^ready$", "offset": "end"}
+		act := healthy.LogLine("app.log", regexp.MustCompile("^ready$")).FromEnd().Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+func writeLog(t *testing.T, fn, s string) {
+	if err := os.WriteFile(fn, []byte(s), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func appendLog(t *testing.T, fn, s string) {
+	f, err := os.OpenFile(fn, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(s); err != nil {
+		t.Fatal(err)
+	}
+}
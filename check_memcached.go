@@ -0,0 +1,68 @@
+package healthy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MemcachedCheck represents a Memcached health check.
+type MemcachedCheck struct {
+	addr    string
+	timeout time.Duration
+}
+
+// Memcached returns a Memcached health check.
+// The check sends the version command using the text protocol and returns
+// nil once the server responds.
+func Memcached(addr string) *MemcachedCheck {
+	return &MemcachedCheck{
+		addr:    withDefaultPort(addr, "11211"),
+		timeout: time.Second,
+	}
+}
+
+// Timeout specifies the connection timeout.
+func (c *MemcachedCheck) Timeout(t time.Duration) *MemcachedCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the server responds to the version command. The
+// server version is stored in the context metadata.
+func (c *MemcachedCheck) Healthy(ctx context.Context) error {
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte("version\r\n")); err != nil {
+		return err
+	}
+
+	line, err := readLine(bufio.NewReader(conn))
+	if err != nil {
+		return err
+	}
+
+	version, ok := strings.CutPrefix(line, "VERSION ")
+	if !ok {
+		return fmt.Errorf("unexpected response: %q", line)
+	}
+
+	GetContextMetadata(ctx).Set("version", version)
+	conn.Write([]byte("quit\r\n"))
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *MemcachedCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    "memcached",
+		"target":  c.addr,
+		"timeout": c.timeout.String(),
+	}
+}
@@ -0,0 +1,121 @@
+package healthy_test
+
+import (
+	"bufio"
+	"context"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestMemcachedCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		err      string
+	}{
+		{
+			name:     "should return nil if the server responds",
+			response: "VERSION 1.6.22\r\n",
+		},
+		{
+			name:     "should return an error if the response is unexpected",
+			response: "ERROR\r\n",
+			err:      "unexpected response",
+		},
+		{
+			name: "should return an error if the connection is closed",
+			err:  "EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startLineServer(t, func(line string) string {
+				if line == "version" {
+					return tt.response
+				}
+				return ""
+			})
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			err := healthy.Memcached(addr).Timeout(100 * time.Millisecond).Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				if act, exp := md.Get("version"), "1.6.22"; act != exp {
+					t.Errorf("got %v, expected %s", act, exp)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+		})
+	}
+
+	t.Run("should not apply a deadline if the timeout is zero", func(t *testing.T) {
+		addr := startLineServer(t, func(string) string { return "VERSION 1.6.22\r\n" })
+
+		err := healthy.Memcached(addr).Timeout(0).Healthy(context.Background())
+		if err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should return an error on context cancellation", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		addr := startTCPServer(t, func(net.Conn) { <-release })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- healthy.Memcached(addr).Timeout(0).Healthy(ctx)
+		}()
+
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Error("got nil, expected error")
+			}
+		case <-time.After(time.Second):
+			t.Error("got no error, expected cancellation")
+		}
+	})
+}
+
+func TestMemcached_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		exp := healthy.Metadata{"type": "memcached", "target": "cache:11211", "timeout": "2s"}
+		act := healthy.Memcached("cache").Timeout(2 * time.Second).Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+// startLineServer starts a server that writes the handler response for
+// each line received, closing the connection if the response is empty.
+func startLineServer(t *testing.T, fn func(string) string) string {
+	return startTCPServer(t, func(conn net.Conn) {
+		sc := bufio.NewScanner(conn)
+		for sc.Scan() {
+			res := fn(strings.TrimSpace(sc.Text()))
+			if res == "" {
+				return
+			}
+			conn.Write([]byte(res))
+		}
+	})
+}
@@ -0,0 +1,300 @@
+package healthy
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// MongoCheck represents a MongoDB health check.
+type MongoCheck struct {
+	hosts   []string
+	primary bool
+	timeout time.Duration
+	err     error
+}
+
+const mongoOpMsg = 2013
+
+var mongoRequestID atomic.Int32
+
+// Mongo returns a MongoDB health check for the supplied connection URI, for
+// example "mongodb://db1:27017,db2:27017/?replicaSet=rs0". An invalid URI
+// is returned as a fatal error by the check.
+//
+// The check sends a hello command using OP_MSG and returns nil once a host
+// responds. The replica set name and role are stored in the context
+// metadata.
+func Mongo(uri string) *MongoCheck {
+	c := &MongoCheck{timeout: time.Second}
+	c.hosts, c.err = parseMongoHosts(uri)
+	return c
+}
+
+// RequirePrimary specifies that a host must be writable, either as the
+// replica set primary or a standalone server. Replica sets elect a primary
+// shortly after starting.
+func (c *MongoCheck) RequirePrimary() *MongoCheck {
+	c.primary = true
+	return c
+}
+
+// Timeout specifies the timeout for each host.
+func (c *MongoCheck) Timeout(t time.Duration) *MongoCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if a host responds to the hello command and, if
+// required, is the writable primary.
+func (c *MongoCheck) Healthy(ctx context.Context) error {
+	if c.err != nil {
+		return Fatal(c.err)
+	}
+
+	var errs []error
+	for _, h := range c.hosts {
+		res, err := c.hello(ctx, h)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h, err))
+			continue
+		}
+
+		role := mongoRole(res)
+		if c.primary && res["isWritablePrimary"] != true {
+			errs = append(errs, fmt.Errorf("%s: node is %s", h, role))
+			continue
+		}
+
+		md := GetContextMetadata(ctx)
+		md.Set("host", h)
+		md.Set("role", role)
+		if name, ok := res["setName"].(string); ok {
+			md.Set("replica_set", name)
+		}
+		return nil
+	}
+
+	return errors.Join(errs...)
+}
+
+// Metadata returns the check metadata.
+func (c *MongoCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    "mongo",
+		"target":  strings.Join(c.hosts, ","),
+		"timeout": c.timeout.String(),
+	}
+}
+
+func (c *MongoCheck) hello(ctx context.Context, host string) (map[string]any, error) {
+	conn, err := dialDeadline(ctx, "tcp", host, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := mongoCommand(conn, "hello")
+	if err != nil {
+		return nil, err
+	}
+	if ok, _ := res["ok"].(float64); ok != 1 {
+		// servers prior to 4.4.2 do not support hello
+		if res, err = mongoCommand(conn, "isMaster"); err != nil {
+			return nil, err
+		}
+		res["isWritablePrimary"] = res["ismaster"]
+	}
+	if ok, _ := res["ok"].(float64); ok != 1 {
+		return nil, fmt.Errorf("command failed: %v", res["errmsg"])
+	}
+
+	return res, nil
+}
+
+func mongoRole(res map[string]any) string {
+	switch {
+	case res["isWritablePrimary"] == true:
+		if res["msg"] == "isdbgrid" {
+			return "mongos"
+		}
+		if _, ok := res["setName"]; !ok {
+			return "standalone"
+		}
+		return "primary"
+	case res["secondary"] == true:
+		return "secondary"
+	case res["arbiterOnly"] == true:
+		return "arbiter"
+	default:
+		return "other"
+	}
+}
+
+func mongoCommand(rw io.ReadWriter, cmd string) (map[string]any, error) {
+	doc := bsonDocument(bsonInt32(cmd, 1), bsonString("$db", "admin"))
+
+	body := append(make([]byte, 4), 0) // flag bits and section kind
+	body = append(body, doc...)
+
+	h := make([]byte, 16)
+	binary.LittleEndian.PutUint32(h[0:], uint32(16+len(body)))
+	binary.LittleEndian.PutUint32(h[4:], uint32(mongoRequestID.Add(1)))
+	binary.LittleEndian.PutUint32(h[12:], mongoOpMsg)
+
+	if _, err := rw.Write(append(h, body...)); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(rw, h); err != nil {
+		return nil, err
+	}
+	n := int(binary.LittleEndian.Uint32(h))
+	if n < 21 || n > 48*1024*1024 {
+		return nil, errors.New("invalid message length")
+	}
+	if op := binary.LittleEndian.Uint32(h[12:]); op != mongoOpMsg {
+		return nil, fmt.Errorf("unexpected op code: %d", op)
+	}
+
+	b := make([]byte, n-16)
+	if _, err := io.ReadFull(rw, b); err != nil {
+		return nil, err
+	}
+	if b[4] != 0 {
+		return nil, fmt.Errorf("unexpected section kind: %d", b[4])
+	}
+
+	return bsonDecode(b[5:])
+}
+
+func bsonDocument(elems ...[]byte) []byte {
+	b := make([]byte, 4)
+	for _, e := range elems {
+		b = append(b, e...)
+	}
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b, uint32(len(b)))
+	return b
+}
+
+func bsonInt32(key string, v int32) []byte {
+	b := append(append([]byte{0x10}, key...), 0)
+	return binary.LittleEndian.AppendUint32(b, uint32(v))
+}
+
+func bsonString(key, v string) []byte {
+	b := append(append([]byte{0x02}, key...), 0)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(v)+1))
+	return append(append(b, v...), 0)
+}
+
+// bsonDecode decodes the top level scalar values of a BSON document.
+// Embedded documents and arrays are skipped.
+func bsonDecode(b []byte) (map[string]any, error) {
+	errInvalid := errors.New("invalid bson document")
+
+	if len(b) < 5 {
+		return nil, errInvalid
+	}
+	n := int(binary.LittleEndian.Uint32(b))
+	if n > len(b) || n < 5 {
+		return nil, errInvalid
+	}
+	b = b[4 : n-1]
+
+	res := map[string]any{}
+	for len(b) > 0 {
+		t := b[0]
+		key, rest, ok := bytes.Cut(b[1:], []byte{0})
+		if !ok {
+			return nil, errInvalid
+		}
+		b = rest
+
+		var size int
+		switch t {
+		case 0x01: // double
+			size = 8
+			if len(b) >= size {
+				res[string(key)] = math.Float64frombits(binary.LittleEndian.Uint64(b))
+			}
+		case 0x02: // string
+			if len(b) < 4 {
+				return nil, errInvalid
+			}
+			size = 4 + int(binary.LittleEndian.Uint32(b))
+			if len(b) >= size && size > 4 {
+				res[string(key)] = string(b[4 : size-1])
+			}
+		case 0x03, 0x04: // document, array
+			if len(b) < 4 {
+				return nil, errInvalid
+			}
+			size = int(binary.LittleEndian.Uint32(b))
+		case 0x05: // binary
+			if len(b) < 4 {
+				return nil, errInvalid
+			}
+			size = 5 + int(binary.LittleEndian.Uint32(b))
+		case 0x07: // object id
+			size = 12
+		case 0x08: // bool
+			size = 1
+			if len(b) >= size {
+				res[string(key)] = b[0] == 1
+			}
+		case 0x09, 0x11, 0x12: // datetime, timestamp, int64
+			size = 8
+			if t == 0x12 && len(b) >= size {
+				res[string(key)] = int64(binary.LittleEndian.Uint64(b))
+			}
+		case 0x0a, 0x7f, 0xff: // null, max key, min key
+		case 0x10: // int32
+			size = 4
+			if len(b) >= size {
+				res[string(key)] = int32(binary.LittleEndian.Uint32(b))
+			}
+		case 0x13: // decimal128
+			size = 16
+		default:
+			return nil, fmt.Errorf("unsupported bson type: 0x%02x", t)
+		}
+
+		if size < 0 || size > len(b) {
+			return nil, errInvalid
+		}
+		b = b[size:]
+	}
+
+	return res, nil
+}
+
+func parseMongoHosts(uri string) ([]string, error) {
+	u, err := parseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "mongodb" {
+		return nil, errors.New("uri scheme must be mongodb")
+	}
+
+	var hosts []string
+	for h := range strings.SplitSeq(u.Host, ",") {
+		if h != "" {
+			hosts = append(hosts, withDefaultPort(h, "27017"))
+		}
+	}
+	if len(hosts) < 1 {
+		return nil, errors.New("uri must contain a host")
+	}
+
+	return hosts, nil
+}
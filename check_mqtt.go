@@ -0,0 +1,245 @@
+package healthy
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// MQTTCheck represents an MQTT broker health check.
+type MQTTCheck struct {
+	addr     string
+	version  MQTTVersion
+	clientID string
+	user     string
+	password string
+	hasAuth  bool
+	timeout  time.Duration
+}
+
+// MQTTVersion represents an MQTT protocol level.
+type MQTTVersion byte
+
+const (
+	// MQTT311 represents MQTT version 3.1.1.
+	MQTT311 MQTTVersion = 4
+	// MQTT5 represents MQTT version 5.
+	MQTT5 MQTTVersion = 5
+)
+
+const (
+	mqttConnect    = 0x10
+	mqttConnack    = 0x20
+	mqttDisconnect = 0xe0
+
+	mqttFlagUsername     = 0x80
+	mqttFlagPassword     = 0x40
+	mqttFlagCleanSession = 0x02
+
+	mqttMaxPacketSize = 64 * 1024
+)
+
+// mqttReasons maps CONNACK return codes to messages, with codes that will
+// not be resolved by retrying marked as fatal.
+var mqttReasons = map[MQTTVersion]map[byte]struct {
+	message string
+	fatal   bool
+}{
+	MQTT311: {
+		0x01: {"unacceptable protocol version", true},
+		0x02: {"identifier rejected", true},
+		0x03: {"server unavailable", false},
+		0x04: {"bad username or password", true},
+		0x05: {"not authorized", true},
+	},
+	MQTT5: {
+		0x80: {"unspecified error", false},
+		0x81: {"malformed packet", true},
+		0x82: {"protocol error", true},
+		0x83: {"implementation specific error", false},
+		0x84: {"unsupported protocol version", true},
+		0x85: {"client identifier not valid", true},
+		0x86: {"bad username or password", true},
+		0x87: {"not authorized", true},
+		0x88: {"server unavailable", false},
+		0x89: {"server busy", false},
+		0x8a: {"banned", true},
+		0x8c: {"bad authentication method", true},
+		0x9c: {"use another server", false},
+		0x9d: {"server moved", false},
+		0x9f: {"connection rate exceeded", false},
+	},
+}
+
+// MQTT returns an MQTT broker health check.
+// The check sends a CONNECT packet, validates the CONNACK return code and
+// disconnects. Credential and authorization errors are returned as fatal
+// errors. MQTT 3.1.1 is used by default.
+func MQTT(addr string) *MQTTCheck {
+	return &MQTTCheck{
+		addr:    withDefaultPort(addr, "1883"),
+		version: MQTT311,
+		timeout: time.Second,
+	}
+}
+
+// Version specifies the protocol version.
+func (c *MQTTCheck) Version(v MQTTVersion) *MQTTCheck {
+	c.version = v
+	return c
+}
+
+// ClientID specifies the client identifier. A random identifier is used
+// by default.
+func (c *MQTTCheck) ClientID(id string) *MQTTCheck {
+	c.clientID = id
+	return c
+}
+
+// Credentials specifies the username and password.
+func (c *MQTTCheck) Credentials(user, password string) *MQTTCheck {
+	c.user = user
+	c.password = password
+	c.hasAuth = true
+	return c
+}
+
+// Timeout specifies the connection timeout.
+func (c *MQTTCheck) Timeout(t time.Duration) *MQTTCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the broker accepts the connection.
+func (c *MQTTCheck) Healthy(ctx context.Context) error {
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write(c.connectPacket()); err != nil {
+		return err
+	}
+
+	typ, body, err := readMQTTPacket(bufio.NewReader(conn))
+	if err != nil {
+		return err
+	}
+	if typ != mqttConnack || len(body) < 2 {
+		return fmt.Errorf("unexpected packet: %#x", typ)
+	}
+	if err = c.connackError(body[1]); err != nil {
+		return err
+	}
+
+	// a v5 disconnect without a reason code indicates normal disconnection
+	conn.Write([]byte{mqttDisconnect, 0})
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *MQTTCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "mqtt",
+		"target":  c.addr,
+		"version": c.version.String(),
+		"timeout": c.timeout.String(),
+	}
+	if c.clientID != "" {
+		md["client_id"] = c.clientID
+	}
+	return md
+}
+
+// String returns the protocol version name.
+func (v MQTTVersion) String() string {
+	switch v {
+	case MQTT311:
+		return "3.1.1"
+	case MQTT5:
+		return "5"
+	default:
+		return fmt.Sprintf("level %d", byte(v))
+	}
+}
+
+func (c *MQTTCheck) connectPacket() []byte {
+	id := c.clientID
+	if id == "" {
+		b := make([]byte, 8)
+		rand.Read(b)
+		id = "healthy-" + hex.EncodeToString(b)
+	}
+
+	flags := byte(mqttFlagCleanSession)
+	if c.hasAuth {
+		flags |= mqttFlagUsername | mqttFlagPassword
+	}
+
+	b := appendMQTTString(nil, "MQTT")
+	b = append(b, byte(c.version), flags, 0, 0) // keep alive disabled
+	if c.version >= MQTT5 {
+		b = append(b, 0) // properties
+	}
+
+	b = appendMQTTString(b, id)
+	if c.hasAuth {
+		b = appendMQTTString(b, c.user)
+		b = appendMQTTString(b, c.password)
+	}
+
+	// remaining length is a variable byte integer, equivalent to a uvarint
+	p := binary.AppendUvarint([]byte{mqttConnect}, uint64(len(b)))
+	return append(p, b...)
+}
+
+func (c *MQTTCheck) connackError(code byte) error {
+	if code == 0 {
+		return nil
+	}
+
+	r, ok := mqttReasons[c.version][code]
+	if !ok {
+		return fmt.Errorf("connection refused: code %#x", code)
+	}
+
+	err := fmt.Errorf("connection refused: %s", r.message)
+	if r.fatal {
+		return Fatal(err)
+	}
+	return err
+}
+
+func readMQTTPacket(r *bufio.Reader) (byte, []byte, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if n > mqttMaxPacketSize {
+		return 0, nil, errors.New("invalid packet size")
+	}
+
+	b := make([]byte, n)
+	if _, err = io.ReadFull(r, b); err != nil {
+		return 0, nil, err
+	}
+
+	return typ & 0xf0, b, nil
+}
+
+func appendMQTTString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,198 @@
+package healthy_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestMQTTCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    byte
+		setup   func(*healthy.MQTTCheck)
+		connect mqttConnect
+		err     string
+		fatal   bool
+	}{
+		{
+			name:    "should return nil if the connection is accepted",
+			connect: mqttConnect{level: 4, disconnect: true},
+		},
+		{
+			name: "should send the client id and credentials",
+			setup: func(c *healthy.MQTTCheck) {
+				c.ClientID("probe").Credentials("app", "secret")
+			},
+			connect: mqttConnect{level: 4, clientID: "probe", user: "app", password: "secret", disconnect: true},
+		},
+		{
+			name:    "should connect using mqtt 5",
+			setup:   func(c *healthy.MQTTCheck) { c.Version(healthy.MQTT5).ClientID("probe") },
+			connect: mqttConnect{level: 5, clientID: "probe", disconnect: true},
+		},
+		{
+			name:    "should return an error if the server is unavailable",
+			code:    0x03,
+			connect: mqttConnect{level: 4},
+			err:     "server unavailable",
+		},
+		{
+			name:    "should return a fatal error if the credentials are invalid",
+			code:    0x04,
+			setup:   func(c *healthy.MQTTCheck) { c.Credentials("app", "invalid") },
+			connect: mqttConnect{level: 4, user: "app", password: "invalid"},
+			err:     "bad username or password",
+			fatal:   true,
+		},
+		{
+			name:    "should return a fatal error if the client is not authorized",
+			code:    0x05,
+			connect: mqttConnect{level: 4},
+			err:     "not authorized",
+			fatal:   true,
+		},
+		{
+			name:    "should return an error if the mqtt 5 server is busy",
+			code:    0x89,
+			setup:   func(c *healthy.MQTTCheck) { c.Version(healthy.MQTT5) },
+			connect: mqttConnect{level: 5},
+			err:     "server busy",
+		},
+		{
+			name:    "should return a fatal error if the mqtt 5 client is not authorized",
+			code:    0x87,
+			setup:   func(c *healthy.MQTTCheck) { c.Version(healthy.MQTT5) },
+			connect: mqttConnect{level: 5},
+			err:     "not authorized",
+			fatal:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, connects := startFakeMQTT(t, tt.code)
+
+			c := healthy.MQTT(addr).Timeout(100 * time.Millisecond)
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+
+			err := c.Healthy(context.Background())
+			if tt.err == "" && err != nil {
+				t.Errorf("got %v, expected nil", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+
+			act := <-connects
+			if tt.connect.clientID == "" && strings.HasPrefix(act.clientID, "healthy-") {
+				act.clientID = ""
+			}
+			if act != tt.connect {
+				t.Errorf("got %+v, expected %+v", act, tt.connect)
+			}
+		})
+	}
+}
+
+func TestMQTT_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.MQTTCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the default metadata",
+			sut:  healthy.MQTT("mosquitto"),
+			exp:  healthy.Metadata{"type": "mqtt", "target": "mosquitto:1883", "version": "3.1.1", "timeout": "1s"},
+		},
+		{
+			name: "should return the client id metadata",
+			sut:  healthy.MQTT("mosquitto:8883").Version(healthy.MQTT5).ClientID("probe").Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "mqtt", "target": "mosquitto:8883", "version": "5", "client_id": "probe", "timeout": "2s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
+
+type mqttConnect struct {
+	level      byte
+	clientID   string
+	user       string
+	password   string
+	disconnect bool
+}
+
+// startFakeMQTT starts a broker that responds to a single CONNECT packet
+// with the specified return code and reports the received packet.
+func startFakeMQTT(t *testing.T, code byte) (string, <-chan mqttConnect) {
+	ch := make(chan mqttConnect, 1)
+	addr := startTCPServer(t, func(conn net.Conn) {
+		var c mqttConnect
+		defer func() { ch <- c }()
+
+		r := bufio.NewReader(conn)
+		if typ, _ := r.ReadByte(); typ != 0x10 {
+			return
+		}
+		n, _ := binary.ReadUvarint(r)
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return
+		}
+
+		str := func() string {
+			n := int(binary.BigEndian.Uint16(b))
+			s := string(b[2 : 2+n])
+			b = b[2+n:]
+			return s
+		}
+
+		str() // protocol name
+		c.level, b = b[0], b[1:]
+		flags := b[0]
+		b = b[3:] // flags, keep alive
+		if c.level == 5 {
+			b = b[1+int(b[0]):] // properties
+		}
+		c.clientID = str()
+		if flags&0x80 != 0 {
+			c.user = str()
+		}
+		if flags&0x40 != 0 {
+			c.password = str()
+		}
+
+		res := []byte{0x20, 2, 0, code}
+		if c.level == 5 {
+			res = []byte{0x20, 3, 0, code, 0}
+		}
+		conn.Write(res)
+
+		typ, err := r.ReadByte()
+		c.disconnect = err == nil && typ == 0xe0
+	})
+
+	return addr, ch
+}
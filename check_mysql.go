@@ -0,0 +1,462 @@
+package healthy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// MySQLCheck represents a MySQL/MariaDB health check.
+type MySQLCheck struct {
+	addr     string
+	user     string
+	password string
+	database string
+	timeout  time.Duration
+	err      error
+}
+
+// MySQLError represents a MySQL server error.
+type MySQLError struct {
+	Code    uint16
+	State   string
+	Message string
+}
+
+const (
+	mysqlClientLongPassword     = 0x00000001
+	mysqlClientConnectWithDB    = 0x00000008
+	mysqlClientProtocol41       = 0x00000200
+	mysqlClientTransactions     = 0x00002000
+	mysqlClientSecureConnection = 0x00008000
+	mysqlClientPluginAuth       = 0x00080000
+
+	mysqlNativePassword = "mysql_native_password"
+	mysqlCachingSHA2    = "caching_sha2_password"
+
+	mysqlComQuit = 0x01
+	mysqlComPing = 0x0e
+)
+
+// mysqlFatalCodes are server error codes that will not be resolved by retrying.
+var mysqlFatalCodes = map[uint16]bool{
+	1044: true, // ER_DBACCESS_DENIED_ERROR
+	1045: true, // ER_ACCESS_DENIED_ERROR
+	1251: true, // ER_NOT_SUPPORTED_AUTH_MODE
+	1698: true, // ER_ACCESS_DENIED_NO_PASSWORD_ERROR
+}
+
+// MySQL returns a MySQL/MariaDB health check.
+// The DSN can be specified in the go-sql-driver format, for example
+// "user:pass@tcp(db:3306)/app", or as a URL, for example
+// "mysql://user:pass@db:3306/app". An invalid DSN is returned as a fatal
+// error by the check.
+//
+// The check performs the connection handshake and authentication before
+// sending COM_PING, without requiring a driver. Authentication errors are
+// returned as fatal errors.
+func MySQL(dsn string) *MySQLCheck {
+	c, err := parseMySQLDSN(dsn)
+	if err != nil {
+		return &MySQLCheck{timeout: time.Second, err: err}
+	}
+	return c
+}
+
+// Timeout specifies the connection timeout.
+func (c *MySQLCheck) Timeout(t time.Duration) *MySQLCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the server accepts the credentials and responds
+// to a ping. The server version is stored in the context metadata.
+func (c *MySQLCheck) Healthy(ctx context.Context) error {
+	if c.err != nil {
+		return Fatal(c.err)
+	}
+
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	mc := &mysqlConn{r: bufio.NewReader(conn), w: conn}
+
+	version, err := c.handshake(mc)
+	if err != nil {
+		return err
+	}
+	GetContextMetadata(ctx).Set("version", version)
+
+	mc.seq = 0
+	if err = mc.writePacket([]byte{mysqlComPing}); err != nil {
+		return err
+	}
+	if _, err = mc.readResult(); err != nil {
+		return err
+	}
+
+	mc.seq = 0
+	mc.writePacket([]byte{mysqlComQuit})
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *MySQLCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "mysql",
+		"target":  c.addr,
+		"timeout": c.timeout.String(),
+	}
+	if c.database != "" {
+		md["database"] = c.database
+	}
+	return md
+}
+
+// Error returns the server error message.
+func (e *MySQLError) Error() string {
+	return fmt.Sprintf("mysql error %d (%s): %s", e.Code, e.State, e.Message)
+}
+
+func (c *MySQLCheck) handshake(mc *mysqlConn) (string, error) {
+	p, err := mc.readPacket()
+	if err != nil {
+		return "", err
+	}
+	if len(p) > 0 && p[0] == 0xff {
+		return "", parseMySQLError(p)
+	}
+
+	g, err := parseMySQLGreeting(p)
+	if err != nil {
+		return "", err
+	}
+
+	auth, err := mysqlScramble(g.plugin, c.password, g.scramble)
+	if err != nil {
+		return "", err
+	}
+
+	caps := uint32(mysqlClientLongPassword | mysqlClientProtocol41 | mysqlClientTransactions |
+		mysqlClientSecureConnection | mysqlClientPluginAuth)
+	if c.database != "" {
+		caps |= mysqlClientConnectWithDB
+	}
+
+	res := binary.LittleEndian.AppendUint32(nil, caps)
+	res = binary.LittleEndian.AppendUint32(res, 1<<24-1)
+	res = append(res, 45) // utf8mb4_general_ci
+	res = append(res, make([]byte, 23)...)
+	res = append(append(res, c.user...), 0)
+	res = append(append(res, byte(len(auth))), auth...)
+	if c.database != "" {
+		res = append(append(res, c.database...), 0)
+	}
+	res = append(append(res, g.plugin...), 0)
+
+	if err = mc.writePacket(res); err != nil {
+		return "", err
+	}
+
+	if err = c.authenticate(mc, g.plugin, g.scramble); err != nil {
+		return "", err
+	}
+
+	return g.version, nil
+}
+
+func (c *MySQLCheck) authenticate(mc *mysqlConn, plugin string, scramble []byte) error {
+	for {
+		p, err := mc.readResult()
+		if err != nil {
+			return err
+		}
+
+		switch p[0] {
+		case 0x00:
+			return nil
+
+		case 0xfe: // auth switch request
+			name, data, _ := bytes.Cut(p[1:], []byte{0})
+			plugin, scramble = string(name), bytes.TrimSuffix(data, []byte{0})
+
+			auth, err := mysqlScramble(plugin, c.password, scramble)
+			if err != nil {
+				return err
+			}
+			if err = mc.writePacket(auth); err != nil {
+				return err
+			}
+
+		case 0x01: // auth more data
+			if plugin != mysqlCachingSHA2 || len(p) < 2 {
+				return fmt.Errorf("unexpected auth data for %s", plugin)
+			}
+
+			switch p[1] {
+			case 0x03: // fast auth success
+			case 0x04: // full auth required
+				if err = mc.writePacket([]byte{0x02}); err != nil {
+					return err
+				}
+				key, err := mc.readResult()
+				if err != nil {
+					return err
+				}
+
+				enc, err := mysqlEncryptPassword(key[1:], c.password, scramble)
+				if err != nil {
+					return err
+				}
+				if err = mc.writePacket(enc); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unexpected auth data for %s", plugin)
+			}
+
+		default:
+			return fmt.Errorf("unexpected packet: 0x%02x", p[0])
+		}
+	}
+}
+
+type mysqlGreeting struct {
+	version  string
+	scramble []byte
+	plugin   string
+}
+
+func parseMySQLGreeting(p []byte) (*mysqlGreeting, error) {
+	errInvalid := errors.New("invalid server greeting")
+
+	if len(p) < 1 || p[0] != 10 {
+		return nil, Fatal(errInvalid)
+	}
+
+	version, rest, ok := bytes.Cut(p[1:], []byte{0})
+	if !ok || len(rest) < 31 {
+		return nil, errInvalid
+	}
+
+	g := &mysqlGreeting{
+		version:  string(version),
+		scramble: append([]byte{}, rest[4:12]...),
+		plugin:   mysqlNativePassword,
+	}
+
+	caps := uint32(binary.LittleEndian.Uint16(rest[13:])) | uint32(binary.LittleEndian.Uint16(rest[18:]))<<16
+	authLen := int(rest[20])
+	rest = rest[31:]
+
+	if caps&mysqlClientSecureConnection != 0 {
+		n := max(13, authLen-8)
+		if len(rest) < n {
+			return nil, errInvalid
+		}
+		g.scramble = append(g.scramble, bytes.TrimSuffix(rest[:n], []byte{0})...)
+		rest = rest[n:]
+	}
+
+	if caps&mysqlClientPluginAuth != 0 {
+		name, _, _ := bytes.Cut(rest, []byte{0})
+		if len(name) > 0 {
+			g.plugin = string(name)
+		}
+	}
+
+	return g, nil
+}
+
+func parseMySQLError(p []byte) error {
+	if len(p) < 3 {
+		return errors.New("invalid error packet")
+	}
+
+	e := &MySQLError{
+		Code:    binary.LittleEndian.Uint16(p[1:]),
+		Message: string(p[3:]),
+	}
+	if len(p) >= 9 && p[3] == '#' {
+		e.State = string(p[4:9])
+		e.Message = string(p[9:])
+	}
+
+	if mysqlFatalCodes[e.Code] {
+		return Fatal(e)
+	}
+	return e
+}
+
+func mysqlScramble(plugin, password string, scramble []byte) ([]byte, error) {
+	if password == "" {
+		return nil, nil
+	}
+
+	switch plugin {
+	case mysqlNativePassword:
+		// SHA1(password) XOR SHA1(scramble + SHA1(SHA1(password)))
+		h1 := sha1.Sum([]byte(password))
+		h2 := sha1.Sum(h1[:])
+		h3 := sha1.Sum(append(append([]byte{}, scramble[:min(20, len(scramble))]...), h2[:]...))
+		for i := range h1 {
+			h1[i] ^= h3[i]
+		}
+		return h1[:], nil
+
+	case mysqlCachingSHA2:
+		// SHA256(password) XOR SHA256(SHA256(SHA256(password)) + scramble)
+		h1 := sha256.Sum256([]byte(password))
+		h2 := sha256.Sum256(h1[:])
+		h3 := sha256.Sum256(append(h2[:], scramble...))
+		for i := range h1 {
+			h1[i] ^= h3[i]
+		}
+		return h1[:], nil
+
+	default:
+		return nil, Fatal(fmt.Errorf("unsupported auth plugin: %s", plugin))
+	}
+}
+
+func mysqlEncryptPassword(key []byte, password string, scramble []byte) ([]byte, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("invalid server public key")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("invalid server public key")
+	}
+
+	b := append([]byte(password), 0)
+	for i := range b {
+		b[i] ^= scramble[i%len(scramble)]
+	}
+
+	return rsa.EncryptOAEP(sha1.New(), rand.Reader, rsaPub, b, nil)
+}
+
+type mysqlConn struct {
+	r   *bufio.Reader
+	w   io.Writer
+	seq byte
+}
+
+func (c *mysqlConn) readPacket() ([]byte, error) {
+	var h [4]byte
+	if _, err := io.ReadFull(c.r, h[:]); err != nil {
+		return nil, err
+	}
+
+	n := int(h[0]) | int(h[1])<<8 | int(h[2])<<16
+	c.seq = h[3] + 1
+
+	p := make([]byte, n)
+	if _, err := io.ReadFull(c.r, p); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("empty packet")
+	}
+
+	return p, nil
+}
+
+// readResult reads a packet, returning an error for error packets.
+func (c *mysqlConn) readResult() ([]byte, error) {
+	p, err := c.readPacket()
+	if err != nil {
+		return nil, err
+	}
+	if p[0] == 0xff {
+		return nil, parseMySQLError(p)
+	}
+	return p, nil
+}
+
+func (c *mysqlConn) writePacket(p []byte) error {
+	h := []byte{byte(len(p)), byte(len(p) >> 8), byte(len(p) >> 16), c.seq}
+	c.seq++
+
+	_, err := c.w.Write(append(h, p...))
+	return err
+}
+
+func parseMySQLDSN(dsn string) (*MySQLCheck, error) {
+	c := &MySQLCheck{
+		addr:    "127.0.0.1:3306",
+		timeout: time.Second,
+	}
+
+	if strings.HasPrefix(dsn, "mysql://") {
+		u, err := parseURL(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dsn: %w", err)
+		}
+		c.user = u.User.Username()
+		c.password, _ = u.User.Password()
+		c.database = strings.TrimPrefix(u.Path, "/")
+		if u.Host != "" {
+			c.addr = withDefaultPort(u.Host, "3306")
+		}
+		return c, nil
+	}
+
+	// [user[:password]@][net[(addr)]]/dbname[?params]
+	i := strings.LastIndex(dsn, "/")
+	if i < 0 {
+		return nil, errors.New("invalid dsn: missing database separator")
+	}
+	prefix, db := dsn[:i], dsn[i+1:]
+	c.database, _, _ = strings.Cut(db, "?")
+
+	if j := strings.LastIndex(prefix, "@"); j >= 0 {
+		c.user, c.password, _ = strings.Cut(prefix[:j], ":")
+		prefix = prefix[j+1:]
+	}
+
+	if prefix != "" {
+		network, addr, ok := strings.Cut(prefix, "(")
+		if network != "tcp" {
+			return nil, fmt.Errorf("invalid dsn: unsupported network %q", network)
+		}
+		if ok {
+			addr, ok = strings.CutSuffix(addr, ")")
+			if !ok {
+				return nil, errors.New("invalid dsn: invalid address")
+			}
+			c.addr = withDefaultPort(addr, "3306")
+		}
+	}
+
+	return c, nil
+}
+
+func withDefaultPort(addr, port string) string {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return net.JoinHostPort(addr, port)
+	}
+	return addr
+}
@@ -0,0 +1,325 @@
+package healthy_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestMySQLCheck_Healthy(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		server fakeMySQL
+		dsn    string
+		err    string
+		fatal  bool
+	}{
+		{
+			name:   "should return nil for native password auth",
+			server: fakeMySQL{plugin: "mysql_native_password", password: "secret"},
+			dsn:    "root:secret@tcp(%s)/app",
+		},
+		{
+			name:   "should return nil for empty passwords",
+			server: fakeMySQL{plugin: "mysql_native_password"},
+			dsn:    "mysql://root@%s/app",
+		},
+		{
+			name:   "should return nil for caching sha2 fast auth",
+			server: fakeMySQL{plugin: "caching_sha2_password", password: "secret"},
+			dsn:    "root:secret@tcp(%s)/",
+		},
+		{
+			name:   "should return nil for caching sha2 full auth",
+			server: fakeMySQL{plugin: "caching_sha2_password", password: "secret", key: key},
+			dsn:    "mysql://root:secret@%s/app",
+		},
+		{
+			name:   "should return nil after auth switch",
+			server: fakeMySQL{plugin: "caching_sha2_password", switchTo: "mysql_native_password", password: "secret"},
+			dsn:    "root:secret@tcp(%s)/app",
+		},
+		{
+			name:   "should return a fatal error on access denied",
+			server: fakeMySQL{plugin: "mysql_native_password", password: "secret"},
+			dsn:    "root:wrong@tcp(%s)/app",
+			err:    "mysql error 1045 (28000): Access denied",
+			fatal:  true,
+		},
+		{
+			name:   "should return an error on server errors",
+			server: fakeMySQL{greetingErr: true},
+			dsn:    "root:secret@tcp(%s)/app",
+			err:    "mysql error 1040 (08004): Too many connections",
+		},
+		{
+			name:   "should return a fatal error for unsupported plugins",
+			server: fakeMySQL{plugin: "sha256_password", password: "secret"},
+			dsn:    "root:secret@tcp(%s)/app",
+			err:    "unsupported auth plugin",
+			fatal:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTCPServer(t, tt.server.serve)
+			dsn := strings.Replace(tt.dsn, "%s", addr, 1)
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			err := healthy.MySQL(dsn).Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				if act, exp := md.Get("version"), "8.0.36"; act != exp {
+					t.Errorf("got %v, expected %s", act, exp)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+		})
+	}
+
+	t.Run("should return an error if the connection fails", func(t *testing.T) {
+		err := healthy.MySQL("root@tcp(localhost:1)/").Timeout(10 * time.Millisecond).Healthy(context.Background())
+		if err == nil {
+			t.Error("got nil, expected error")
+		}
+	})
+
+	t.Run("should return a fatal error for invalid dsns", func(t *testing.T) {
+		for _, dsn := range []string{"user@unix(/tmp/mysql.sock)/app", "mysql://root:hunter2@db:x%zz/app"} {
+			err := healthy.MySQL(dsn).Healthy(context.Background())
+			if err == nil || !healthy.IsFatal(err) || strings.Contains(err.Error(), "hunter2") {
+				t.Errorf("got %v, expected redacted fatal error", err)
+			}
+		}
+	})
+}
+
+func TestMySQL_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should parse driver dsns",
+			dsn:  "user:p@ss@tcp(db:3307)/app?parseTime=true",
+			exp:  healthy.Metadata{"type": "mysql", "target": "db:3307", "database": "app", "timeout": "1s"},
+		},
+		{
+			name: "should apply the default address",
+			dsn:  "user@/",
+			exp:  healthy.Metadata{"type": "mysql", "target": "127.0.0.1:3306", "timeout": "1s"},
+		},
+		{
+			name: "should parse url dsns",
+			dsn:  "mysql://user:pass@db/app",
+			exp:  healthy.Metadata{"type": "mysql", "target": "db:3306", "database": "app", "timeout": "1s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := healthy.MySQL(tt.dsn).Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+
+}
+
+// fakeMySQL is a minimal MySQL server supporting the connection handshake
+// and COM_PING.
+type fakeMySQL struct {
+	plugin      string
+	switchTo    string
+	password    string
+	key         *rsa.PrivateKey
+	greetingErr bool
+}
+
+func (s fakeMySQL) serve(conn net.Conn) {
+	c := &fakeMySQLConn{r: bufio.NewReader(conn), w: conn}
+	if s.greetingErr {
+		c.write(mysqlErrPacket(1040, "08004", "Too many connections"))
+		return
+	}
+
+	scramble := []byte("abcdefghijklmnopqrst")
+
+	g := append([]byte{10}, "8.0.36\x00"...)
+	g = append(g, 1, 0, 0, 0)
+	g = append(g, scramble[:8]...)
+	g = append(g, 0)
+	g = binary.LittleEndian.AppendUint16(g, 0xffff)
+	g = append(g, 45, 2, 0)
+	g = binary.LittleEndian.AppendUint16(g, 0x00ff|0x0008)
+	g = append(g, 21)
+	g = append(g, make([]byte, 10)...)
+	g = append(append(g, scramble[8:]...), 0)
+	g = append(append(g, s.plugin...), 0)
+	c.write(g)
+
+	res, ok := c.read()
+	if !ok {
+		return
+	}
+	auth := parseHandshakeAuth(res)
+
+	plugin := s.plugin
+	if s.switchTo != "" {
+		plugin = s.switchTo
+		c.write(append(append(append([]byte{0xfe}, plugin...), 0), append(scramble, 0)...))
+		if auth, ok = c.read(); !ok {
+			return
+		}
+	}
+
+	switch plugin {
+	case "mysql_native_password":
+		if !bytes.Equal(auth, nativeScramble(s.password, scramble)) {
+			c.write(mysqlErrPacket(1045, "28000", "Access denied for user 'root'"))
+			return
+		}
+
+	case "caching_sha2_password":
+		if s.key == nil {
+			if !bytes.Equal(auth, sha2Scramble(s.password, scramble)) {
+				c.write(mysqlErrPacket(1045, "28000", "Access denied for user 'root'"))
+				return
+			}
+			c.write([]byte{0x01, 0x03})
+			break
+		}
+
+		c.write([]byte{0x01, 0x04})
+		if req, ok := c.read(); !ok || !bytes.Equal(req, []byte{0x02}) {
+			return
+		}
+
+		der, _ := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+		c.write(append([]byte{0x01}, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...))
+
+		enc, ok := c.read()
+		if !ok {
+			return
+		}
+		b, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, s.key, enc, nil)
+		if err != nil {
+			return
+		}
+		for i := range b {
+			b[i] ^= scramble[i%len(scramble)]
+		}
+		if string(b) != s.password+"\x00" {
+			c.write(mysqlErrPacket(1045, "28000", "Access denied for user 'root'"))
+			return
+		}
+
+	default:
+		c.write(mysqlErrPacket(1251, "08004", "Client does not support authentication protocol"))
+		return
+	}
+
+	okPacket := []byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	c.write(okPacket)
+
+	for {
+		cmd, ok := c.read()
+		if !ok || cmd[0] != 0x0e {
+			return
+		}
+		c.seq = 1
+		c.write(okPacket)
+	}
+}
+
+type fakeMySQLConn struct {
+	r   *bufio.Reader
+	w   io.Writer
+	seq byte
+}
+
+func (c *fakeMySQLConn) read() ([]byte, bool) {
+	var h [4]byte
+	if _, err := io.ReadFull(c.r, h[:]); err != nil {
+		return nil, false
+	}
+	c.seq = h[3] + 1
+
+	p := make([]byte, int(h[0])|int(h[1])<<8|int(h[2])<<16)
+	if _, err := io.ReadFull(c.r, p); err != nil || len(p) == 0 {
+		return nil, false
+	}
+	return p, true
+}
+
+func (c *fakeMySQLConn) write(p []byte) {
+	c.w.Write(append([]byte{byte(len(p)), byte(len(p) >> 8), byte(len(p) >> 16), c.seq}, p...))
+	c.seq++
+}
+
+func parseHandshakeAuth(p []byte) []byte {
+	p = p[32:] // capabilities, max packet, charset and filler
+	_, p, _ = bytes.Cut(p, []byte{0})
+	return p[1 : 1+int(p[0])]
+}
+
+func mysqlErrPacket(code uint16, state, msg string) []byte {
+	p := binary.LittleEndian.AppendUint16([]byte{0xff}, code)
+	return append(append(append(p, '#'), state...), msg...)
+}
+
+func nativeScramble(password string, scramble []byte) []byte {
+	if password == "" {
+		return []byte{}
+	}
+	h1 := sha1.Sum([]byte(password))
+	h2 := sha1.Sum(h1[:])
+	h3 := sha1.Sum(append(append([]byte{}, scramble...), h2[:]...))
+	for i := range h1 {
+		h1[i] ^= h3[i]
+	}
+	return h1[:]
+}
+
+func sha2Scramble(password string, scramble []byte) []byte {
+	h1 := sha256.Sum256([]byte(password))
+	h2 := sha256.Sum256(h1[:])
+	h3 := sha256.Sum256(append(h2[:], scramble...))
+	for i := range h1 {
+		h1[i] ^= h3[i]
+	}
+	return h1[:]
+}
@@ -0,0 +1,174 @@
+package healthy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NATSCheck represents a NATS server health check.
+type NATSCheck struct {
+	addr      string
+	user      string
+	password  string
+	token     string
+	jetstream bool
+	timeout   time.Duration
+}
+
+type natsInfo struct {
+	ServerID     string `json:"server_id"`
+	Version      string `json:"version"`
+	AuthRequired bool   `json:"auth_required"`
+	TLSRequired  bool   `json:"tls_required"`
+	JetStream    bool   `json:"jetstream"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Protocol int    `json:"protocol"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+const natsMaxLineSize = 64 * 1024
+
+// NATS returns a NATS server health check.
+// The check reads the INFO banner, sends CONNECT and PING and returns nil
+// once the server responds with PONG. Authorization errors are returned as
+// fatal errors.
+func NATS(addr string) *NATSCheck {
+	return &NATSCheck{
+		addr:    withDefaultPort(addr, "4222"),
+		timeout: time.Second,
+	}
+}
+
+// Credentials specifies the username and password.
+func (c *NATSCheck) Credentials(user, password string) *NATSCheck {
+	c.user = user
+	c.password = password
+	return c
+}
+
+// Token specifies the authentication token.
+func (c *NATSCheck) Token(token string) *NATSCheck {
+	c.token = token
+	return c
+}
+
+// JetStream specifies that JetStream must be enabled on the server.
+func (c *NATSCheck) JetStream() *NATSCheck {
+	c.jetstream = true
+	return c
+}
+
+// Timeout specifies the connection timeout.
+func (c *NATSCheck) Timeout(t time.Duration) *NATSCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the server accepts the connection and responds to
+// a ping. The server ID and version are stored in the context metadata.
+func (c *NATSCheck) Healthy(ctx context.Context) error {
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	r := bufio.NewReaderSize(conn, natsMaxLineSize)
+
+	line, err := readLine(r)
+	if err != nil {
+		return err
+	}
+	payload, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected banner: %q", line)
+	}
+
+	var info natsInfo
+	if err = json.Unmarshal([]byte(payload), &info); err != nil {
+		return fmt.Errorf("invalid info: %w", err)
+	}
+
+	md := GetContextMetadata(ctx)
+	md.Set("server_id", info.ServerID)
+	md.Set("version", info.Version)
+
+	if info.TLSRequired {
+		return Fatal(errors.New("tls is required"))
+	}
+	if c.jetstream && !info.JetStream {
+		return errors.New("jetstream is not enabled")
+	}
+
+	req, err := json.Marshal(natsConnect{
+		Name:     "healthy",
+		Lang:     "go",
+		Protocol: 1,
+		User:     c.user,
+		Pass:     c.password,
+		Token:    c.token,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", req); err != nil {
+		return err
+	}
+
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return err
+		}
+
+		switch op, _, _ := strings.Cut(line, " "); strings.ToUpper(op) {
+		case "PONG":
+			return nil
+		case "PING":
+			if _, err = conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case "-ERR":
+			return natsError(line)
+		case "+OK", "INFO":
+		default:
+			return fmt.Errorf("unexpected response: %q", line)
+		}
+	}
+}
+
+// Metadata returns the check metadata.
+func (c *NATSCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "nats",
+		"target":  c.addr,
+		"timeout": c.timeout.String(),
+	}
+	if c.jetstream {
+		md["jetstream"] = "true"
+	}
+	return md
+}
+
+func natsError(line string) error {
+	msg := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")
+	err := fmt.Errorf("server error: %s", msg)
+
+	if strings.HasPrefix(strings.ToLower(msg), "authorization violation") {
+		return Fatal(err)
+	}
+	return err
+}
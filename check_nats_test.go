@@ -0,0 +1,187 @@
+package healthy_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestNATSCheck_Healthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		server fakeNATS
+		setup  func(*healthy.NATSCheck)
+		err    string
+		fatal  bool
+	}{
+		{
+			name:   "should return nil if the server responds",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7"}`},
+		},
+		{
+			name:   "should return nil if the credentials are accepted",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7","auth_required":true}`, user: "app", password: "secret"},
+			setup:  func(c *healthy.NATSCheck) { c.Credentials("app", "secret") },
+		},
+		{
+			name:   "should return nil if the token is accepted",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7","auth_required":true}`, token: "s3cr3t"},
+			setup:  func(c *healthy.NATSCheck) { c.Token("s3cr3t") },
+		},
+		{
+			name:   "should return nil if jetstream is enabled",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7","jetstream":true}`},
+			setup:  func(c *healthy.NATSCheck) { c.JetStream() },
+		},
+		{
+			name:   "should respond to server pings",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7"}`, ping: true},
+		},
+		{
+			name:   "should return an error if jetstream is not enabled",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7"}`},
+			setup:  func(c *healthy.NATSCheck) { c.JetStream() },
+			err:    "jetstream is not enabled",
+		},
+		{
+			name:   "should return a fatal error if authorization fails",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7","auth_required":true}`, user: "app", password: "secret"},
+			setup:  func(c *healthy.NATSCheck) { c.Credentials("app", "invalid") },
+			err:    "authorization violation",
+			fatal:  true,
+		},
+		{
+			name:   "should return a fatal error if tls is required",
+			server: fakeNATS{info: `{"server_id":"NABC","version":"2.10.7","tls_required":true}`},
+			err:    "tls is required",
+			fatal:  true,
+		},
+		{
+			name:   "should return an error if the banner is invalid",
+			server: fakeNATS{banner: "220 smtp.example.com ESMTP"},
+			err:    "unexpected banner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTCPServer(t, tt.server.serve)
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			c := healthy.NATS(addr).Timeout(100 * time.Millisecond)
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+
+			err := c.Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				exp := healthy.Metadata{"server_id": "NABC", "version": "2.10.7"}
+				if !maps.Equal(md, exp) {
+					t.Errorf("got %v, expected %v", md, exp)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+		})
+	}
+}
+
+func TestNATS_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.NATSCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the default metadata",
+			sut:  healthy.NATS("nats"),
+			exp:  healthy.Metadata{"type": "nats", "target": "nats:4222", "timeout": "1s"},
+		},
+		{
+			name: "should return the jetstream metadata",
+			sut:  healthy.NATS("nats:4223").JetStream().Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "nats", "target": "nats:4223", "jetstream": "true", "timeout": "2s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
+
+// fakeNATS is a minimal NATS server supporting CONNECT and PING.
+type fakeNATS struct {
+	banner   string
+	info     string
+	user     string
+	password string
+	token    string
+	ping     bool
+}
+
+func (s fakeNATS) serve(conn net.Conn) {
+	if s.banner != "" {
+		fmt.Fprintf(conn, "%s\r\n", s.banner)
+		return
+	}
+	fmt.Fprintf(conn, "INFO %s\r\n", s.info)
+
+	var pending bool
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		op, arg, _ := strings.Cut(strings.TrimSpace(sc.Text()), " ")
+		switch op {
+		case "CONNECT":
+			var req struct {
+				User  string `json:"user"`
+				Pass  string `json:"pass"`
+				Token string `json:"auth_token"`
+			}
+			if err := json.Unmarshal([]byte(arg), &req); err != nil {
+				fmt.Fprint(conn, "-ERR 'Invalid Connect'\r\n")
+				return
+			}
+			if req.User != s.user || req.Pass != s.password || req.Token != s.token {
+				fmt.Fprint(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+			if s.ping {
+				fmt.Fprint(conn, "PING\r\n")
+			}
+		case "PING":
+			// defer the response until the server ping is answered
+			if s.ping {
+				pending = true
+				continue
+			}
+			fmt.Fprint(conn, "PONG\r\n")
+		case "PONG":
+			if pending {
+				fmt.Fprint(conn, "PONG\r\n")
+			}
+		}
+	}
+}
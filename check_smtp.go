@@ -0,0 +1,150 @@
+package healthy
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SMTPCheck represents an SMTP server health check.
+type SMTPCheck struct {
+	addr     string
+	ehlo     string
+	startTLS bool
+	tls      *tls.Config
+	timeout  time.Duration
+}
+
+// SMTP returns an SMTP server health check.
+// The check returns nil once the server sends a 220 service ready greeting.
+func SMTP(addr string) *SMTPCheck {
+	return &SMTPCheck{
+		addr:    withDefaultPort(addr, "25"),
+		timeout: time.Second,
+	}
+}
+
+// EHLO specifies that the EHLO command should be sent with the supplied
+// domain after the greeting.
+func (c *SMTPCheck) EHLO(domain string) *SMTPCheck {
+	c.ehlo = domain
+	return c
+}
+
+// StartTLS specifies that the server must support STARTTLS and complete a
+// TLS handshake using the supplied config. If the config is nil then the
+// server certificate is verified against the host name. EHLO is sent
+// using "localhost" unless otherwise specified.
+func (c *SMTPCheck) StartTLS(cfg *tls.Config) *SMTPCheck {
+	c.startTLS = true
+	c.tls = cfg
+	return c
+}
+
+// Timeout specifies the connection timeout.
+func (c *SMTPCheck) Timeout(t time.Duration) *SMTPCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the server sends a 220 greeting and, if specified,
+// accepts the EHLO and STARTTLS commands. The greeting is stored in the
+// context metadata.
+func (c *SMTPCheck) Healthy(ctx context.Context) error {
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+
+	_, msg, err := tp.ReadResponse(220)
+	if err != nil {
+		return err
+	}
+	GetContextMetadata(ctx).Set("banner", msg)
+
+	if c.ehlo != "" || c.startTLS {
+		ehlo := c.ehlo
+		if ehlo == "" {
+			ehlo = "localhost"
+		}
+
+		ext, err := smtpCommand(tp, 250, "EHLO %s", ehlo)
+		if err != nil {
+			return err
+		}
+
+		if c.startTLS {
+			if !slices.Contains(smtpExtensions(ext), "STARTTLS") {
+				return Fatal(errors.New("starttls not supported"))
+			}
+			if _, err = smtpCommand(tp, 220, "STARTTLS"); err != nil {
+				return err
+			}
+
+			cfg := c.tls
+			if cfg == nil {
+				host, _, _ := net.SplitHostPort(c.addr)
+				cfg = &tls.Config{ServerName: host}
+			}
+
+			tc := tls.Client(conn, cfg)
+			if err = tc.HandshakeContext(ctx); err != nil {
+				return err
+			}
+			tp = textproto.NewConn(tc)
+		}
+	}
+
+	smtpCommand(tp, 221, "QUIT")
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *SMTPCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "smtp",
+		"target":  c.addr,
+		"timeout": c.timeout.String(),
+	}
+	if c.ehlo != "" {
+		md["ehlo"] = c.ehlo
+	}
+	if c.startTLS {
+		md["starttls"] = "true"
+	}
+	return md
+}
+
+func smtpCommand(tp *textproto.Conn, code int, format string, args ...any) (string, error) {
+	id, err := tp.Cmd(format, args...)
+	if err != nil {
+		return "", err
+	}
+
+	tp.StartResponse(id)
+	defer tp.EndResponse(id)
+
+	_, msg, err := tp.ReadResponse(code)
+	return msg, err
+}
+
+// smtpExtensions returns the extension keywords from an EHLO response,
+// excluding the initial greeting line.
+func smtpExtensions(msg string) []string {
+	lines := strings.Split(msg, "\n")
+	ext := make([]string, 0, len(lines))
+	for _, l := range lines[1:] {
+		if f := strings.Fields(l); len(f) > 0 {
+			ext = append(ext, strings.ToUpper(f[0]))
+		}
+	}
+	return ext
+}
@@ -0,0 +1,177 @@
+package healthy_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestSMTPCheck_Healthy(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	tests := []struct {
+		name   string
+		server fakeSMTP
+		setup  func(*healthy.SMTPCheck)
+		err    string
+		fatal  bool
+	}{
+		{
+			name:   "should return nil if the server is ready",
+			server: fakeSMTP{greeting: "220 mailpit ESMTP\r\n"},
+		},
+		{
+			name:   "should return nil for multi-line greetings",
+			server: fakeSMTP{greeting: "220-mailpit ESMTP\r\n220 ready\r\n"},
+		},
+		{
+			name:   "should return nil if ehlo is accepted",
+			server: fakeSMTP{greeting: "220 mailpit ESMTP\r\n"},
+			setup:  func(c *healthy.SMTPCheck) { c.EHLO("example.com") },
+		},
+		{
+			name:   "should return nil if starttls succeeds",
+			server: fakeSMTP{greeting: "220 mailpit ESMTP\r\n", tls: srv.TLS},
+			setup: func(c *healthy.SMTPCheck) {
+				c.StartTLS(&tls.Config{ServerName: "example.com", RootCAs: roots})
+			},
+		},
+		{
+			name:   "should return an error if the server is not ready",
+			server: fakeSMTP{greeting: "421 service not available\r\n"},
+			err:    "service not available",
+		},
+		{
+			name:   "should return a fatal error if starttls is not supported",
+			server: fakeSMTP{greeting: "220 mailpit ESMTP\r\n"},
+			setup:  func(c *healthy.SMTPCheck) { c.StartTLS(nil) },
+			err:    "starttls not supported",
+			fatal:  true,
+		},
+		{
+			name:   "should return an error if the certificate is invalid",
+			server: fakeSMTP{greeting: "220 mailpit ESMTP\r\n", tls: srv.TLS},
+			setup:  func(c *healthy.SMTPCheck) { c.StartTLS(&tls.Config{ServerName: "example.com"}) },
+			err:    "certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTCPServer(t, tt.server.serve)
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			c := healthy.SMTP(addr).Timeout(time.Second)
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+
+			err := c.Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				if act, _ := md.Get("banner").(string); !strings.HasPrefix(act, "mailpit ESMTP") {
+					t.Errorf("got %v, expected mailpit ESMTP", act)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+		})
+	}
+}
+
+func TestSMTP_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.SMTPCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the default metadata",
+			sut:  healthy.SMTP("mail"),
+			exp:  healthy.Metadata{"type": "smtp", "target": "mail:25", "timeout": "1s"},
+		},
+		{
+			name: "should return the starttls metadata",
+			sut:  healthy.SMTP("mail:587").EHLO("example.com").StartTLS(nil).Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "smtp", "target": "mail:587", "ehlo": "example.com", "starttls": "true", "timeout": "2s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
+
+// fakeSMTP is a minimal SMTP server supporting EHLO, STARTTLS and QUIT.
+// STARTTLS is advertised if a TLS config is specified.
+type fakeSMTP struct {
+	greeting string
+	tls      *tls.Config
+}
+
+func (s fakeSMTP) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+
+	fmt.Fprint(conn, s.greeting)
+	if !strings.HasPrefix(s.greeting, "220") {
+		return
+	}
+
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO":
+			if s.tls != nil {
+				fmt.Fprintf(conn, "250-mailpit greets %s\r\n250-SIZE 0\r\n250 STARTTLS\r\n", arg)
+			} else {
+				fmt.Fprintf(conn, "250-mailpit greets %s\r\n250 SIZE 0\r\n", arg)
+			}
+		case "STARTTLS":
+			fmt.Fprint(conn, "220 ready to start tls\r\n")
+			tc := tls.Server(conn, s.tls)
+			if err := tc.Handshake(); err != nil {
+				return
+			}
+			conn, r = tc, bufio.NewReader(tc)
+		case "QUIT":
+			fmt.Fprint(conn, "221 bye\r\n")
+			return
+		default:
+			fmt.Fprint(conn, "502 command not implemented\r\n")
+		}
+	}
+}
@@ -46,3 +46,45 @@ func (c *TCPCheck) Metadata() Metadata {
 		"timeout": c.timeout.String(),
 	}
 }
+
+// dialDeadline connects to the address and sets a connection deadline of
+// the earlier of the timeout and the context deadline. A timeout of zero
+// means no timeout. Reads and writes are interrupted if the context is
+// cancelled before the connection is closed.
+func dialDeadline(ctx context.Context, network, addr string, timeout time.Duration) (net.Conn, error) {
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+
+	return &deadlineConn{Conn: conn, stop: stop}, nil
+}
+
+// deadlineConn releases the context cancellation func on close.
+type deadlineConn struct {
+	net.Conn
+	stop func() bool
+}
+
+// Close releases the context cancellation func and closes the connection.
+func (c *deadlineConn) Close() error {
+	c.stop()
+	return c.Conn.Close()
+}
@@ -48,6 +48,31 @@ func TestTCP_Metadata(t *testing.T) {
 	})
 }
 
+// startTCPServer starts a server that calls the handler for each accepted
+// connection and closes the connection once the handler returns.
+func startTCPServer(t *testing.T, fn func(net.Conn)) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				fn(conn)
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
 func getFreePort() int {
 	a, err := net.ResolveTCPAddr("tcp", "localhost:0")
 	if err != nil {
@@ -0,0 +1,86 @@
+package healthy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+)
+
+// UDPCheck represents a UDP request/response health check.
+type UDPCheck struct {
+	addr    string
+	timeout time.Duration
+	payload []byte
+	match   func([]byte) bool
+}
+
+// UDP returns a UDP health check.
+// By default an empty datagram is sent and any reply is accepted.
+func UDP(addr string) *UDPCheck {
+	return &UDPCheck{
+		addr:    addr,
+		timeout: time.Second,
+		match:   func([]byte) bool { return true },
+	}
+}
+
+// Timeout specifies the timeout for the request and response.
+func (c *UDPCheck) Timeout(t time.Duration) *UDPCheck {
+	c.timeout = t
+	return c
+}
+
+// Payload specifies the request payload.
+func (c *UDPCheck) Payload(b []byte) *UDPCheck {
+	c.payload = b
+	return c
+}
+
+// Expect specifies the expected response prefix.
+func (c *UDPCheck) Expect(prefix []byte) *UDPCheck {
+	return c.ExpectFunc(func(b []byte) bool {
+		return bytes.HasPrefix(b, prefix)
+	})
+}
+
+// ExpectFunc specifies a predicate that the response must satisfy.
+func (c *UDPCheck) ExpectFunc(fn func([]byte) bool) *UDPCheck {
+	c.match = fn
+	return c
+}
+
+// Healthy returns nil if the target address replies to the payload with
+// the expected response within the timeout.
+func (c *UDPCheck) Healthy(ctx context.Context) error {
+	conn, err := dialDeadline(ctx, "udp", c.addr, c.timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write(c.payload); err != nil {
+		return err
+	}
+
+	b := make([]byte, 65535)
+	n, err := conn.Read(b)
+	if err != nil {
+		return err
+	}
+
+	if !c.match(b[:n]) {
+		return errors.New("unexpected response")
+	}
+
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *UDPCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    "udp",
+		"target":  c.addr,
+		"timeout": c.timeout.String(),
+	}
+}
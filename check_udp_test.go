@@ -0,0 +1,95 @@
+package healthy_test
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestUDPCheck_Healthy(t *testing.T) {
+	addr := startUDPEcho(t)
+
+	tests := []struct {
+		name string
+		sut  *healthy.UDPCheck
+		err  bool
+	}{
+		{
+			name: "should return an error on timeout",
+			sut:  healthy.UDP(addr).Payload([]byte("ignore")).Timeout(10 * time.Millisecond),
+			err:  true,
+		},
+		{
+			name: "should return an error on unexpected response",
+			sut:  healthy.UDP(addr).Payload([]byte("ping")).Expect([]byte("pong")),
+			err:  true,
+		},
+		{
+			name: "should return nil on any response",
+			sut:  healthy.UDP(addr).Payload([]byte("ping")),
+		},
+		{
+			name: "should return nil on expected prefix",
+			sut:  healthy.UDP(addr).Payload([]byte("ping")).Expect([]byte("pi")),
+		},
+		{
+			name: "should return nil on expected predicate",
+			sut: healthy.UDP(addr).Payload([]byte("ping")).ExpectFunc(func(b []byte) bool {
+				return len(b) == 4
+			}),
+		},
+		{
+			name: "should not apply a deadline if the timeout is zero",
+			sut:  healthy.UDP(addr).Payload([]byte("ping")).Timeout(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sut.Healthy(context.Background())
+			if (err != nil) != tt.err {
+				t.Errorf("got %v, expected error %t", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestUDP_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		const target = "localhost:53"
+		exp := healthy.Metadata{"type": "udp", "target": target, "timeout": "500ms"}
+		act := healthy.UDP(target).Timeout(500 * time.Millisecond).Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+// startUDPEcho starts a udp server that echoes all datagrams other than "ignore".
+func startUDPEcho(t *testing.T) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pc.Close() })
+
+	go func() {
+		b := make([]byte, 1024)
+		for {
+			n, addr, err := pc.ReadFrom(b)
+			if err != nil {
+				return
+			}
+			if !bytes.Equal(b[:n], []byte("ignore")) {
+				pc.WriteTo(b[:n], addr)
+			}
+		}
+	}()
+
+	return pc.LocalAddr().String()
+}
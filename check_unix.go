@@ -0,0 +1,49 @@
+package healthy
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// UnixCheck represents a unix domain socket health check.
+type UnixCheck struct {
+	path    string
+	timeout time.Duration
+}
+
+// Unix returns a unix domain socket health check.
+func Unix(path string) *UnixCheck {
+	return &UnixCheck{
+		path:    path,
+		timeout: time.Second,
+	}
+}
+
+// Timeout specifies the dial timeout.
+func (c *UnixCheck) Timeout(t time.Duration) *UnixCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if a stream connection can be established with
+// the target socket.
+func (c *UnixCheck) Healthy(ctx context.Context) error {
+	d := net.Dialer{Timeout: c.timeout}
+	conn, err := d.DialContext(ctx, "unix", c.path)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *UnixCheck) Metadata() Metadata {
+	return Metadata{
+		"type":    "unix",
+		"target":  c.path,
+		"timeout": c.timeout.String(),
+	}
+}
@@ -0,0 +1,61 @@
+package healthy_test
+
+import (
+	"context"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestUnixCheck_Healthy(t *testing.T) {
+	path := tempSocket(t)
+
+	t.Run("should return an error on failure", func(t *testing.T) {
+		sut := healthy.Unix(path).Timeout(time.Millisecond)
+		err := sut.Healthy(context.Background())
+		if err == nil {
+			t.Error("got nil, expected error")
+		}
+	})
+
+	t.Run("should return nil on success", func(t *testing.T) {
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.Close()
+
+		sut := healthy.Unix(path)
+		err = sut.Healthy(context.Background())
+		if err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+}
+
+func TestUnix_Metadata(t *testing.T) {
+	t.Run("should return the check metadata", func(t *testing.T) {
+		const target = "/var/run/test.sock"
+		exp := healthy.Metadata{"type": "unix", "target": target, "timeout": "500ms"}
+		act := healthy.Unix(target).Timeout(500 * time.Millisecond).Metadata()
+		if !maps.Equal(act, exp) {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+func tempSocket(t *testing.T) string {
+	// avoid t.TempDir as socket paths are limited in length
+	dir, err := os.MkdirTemp("", "healthy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return filepath.Join(dir, "test.sock")
+}
@@ -0,0 +1,137 @@
+package healthy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ZooKeeperCheck represents a ZooKeeper health check.
+type ZooKeeperCheck struct {
+	addr    string
+	states  []string
+	timeout time.Duration
+}
+
+const zkMaxResponseSize = 64 * 1024
+
+// ZooKeeper returns a ZooKeeper health check.
+// The check sends the ruok four letter word command and returns nil once
+// the server responds with imok. The command must be included in the
+// 4lw.commands.whitelist server setting, otherwise a fatal error is
+// returned.
+func ZooKeeper(addr string) *ZooKeeperCheck {
+	return &ZooKeeperCheck{
+		addr:    withDefaultPort(addr, "2181"),
+		timeout: time.Second,
+	}
+}
+
+// ServerState specifies that the server must be in one of the supplied
+// states, as reported by the mntr command. If no states are specified then
+// leader, follower and standalone are accepted.
+func (c *ZooKeeperCheck) ServerState(states ...string) *ZooKeeperCheck {
+	if len(states) < 1 {
+		states = []string{"leader", "follower", "standalone"}
+	}
+	c.states = states
+	return c
+}
+
+// Timeout specifies the connection timeout.
+func (c *ZooKeeperCheck) Timeout(t time.Duration) *ZooKeeperCheck {
+	c.timeout = t
+	return c
+}
+
+// Healthy returns nil if the server is running without error and, if
+// specified, is in one of the required states. The server state and
+// version are stored in the context metadata when using mntr.
+func (c *ZooKeeperCheck) Healthy(ctx context.Context) error {
+	if c.states == nil {
+		res, err := c.command(ctx, "ruok")
+		if err != nil {
+			return err
+		}
+		if res != "imok" {
+			return fmt.Errorf("unexpected response: %q", res)
+		}
+		return nil
+	}
+
+	res, err := c.command(ctx, "mntr")
+	if err != nil {
+		return err
+	}
+
+	stats := map[string]string{}
+	sc := bufio.NewScanner(strings.NewReader(res))
+	for sc.Scan() {
+		if k, v, ok := strings.Cut(sc.Text(), "\t"); ok {
+			stats[k] = v
+		}
+	}
+
+	state, ok := stats["zk_server_state"]
+	if !ok {
+		return fmt.Errorf("unexpected response: %q", res)
+	}
+
+	md := GetContextMetadata(ctx)
+	md.Set("server_state", state)
+	if v, ok := stats["zk_version"]; ok {
+		md.Set("version", v)
+	}
+
+	if !slices.Contains(c.states, state) {
+		return fmt.Errorf("server state is %s", state)
+	}
+	return nil
+}
+
+// Metadata returns the check metadata.
+func (c *ZooKeeperCheck) Metadata() Metadata {
+	md := Metadata{
+		"type":    "zookeeper",
+		"target":  c.addr,
+		"timeout": c.timeout.String(),
+	}
+	if c.states != nil {
+		md["states"] = strings.Join(c.states, ",")
+	}
+	return md
+}
+
+// command sends the four letter word and returns the response. The server
+// closes the connection once the response has been written.
+func (c *ZooKeeperCheck) command(ctx context.Context, cmd string) (string, error) {
+	conn, err := dialDeadline(ctx, "tcp", c.addr, c.timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte(cmd)); err != nil {
+		return "", err
+	}
+
+	b, err := io.ReadAll(io.LimitReader(conn, zkMaxResponseSize))
+	if err != nil {
+		return "", err
+	}
+
+	res := strings.TrimSpace(string(b))
+	if strings.Contains(res, "not in the whitelist") {
+		return "", Fatal(fmt.Errorf("%s command is not whitelisted", cmd))
+	}
+	if strings.Contains(res, "not currently serving requests") {
+		return "", errors.New("server is not serving requests")
+	}
+
+	return res, nil
+}
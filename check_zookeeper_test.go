@@ -0,0 +1,137 @@
+package healthy_test
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestZooKeeperCheck_Healthy(t *testing.T) {
+	const mntr = "zk_version\t3.9.1-abc, built on 2023-10-05 19:56 UTC\nzk_server_state\t%s\nzk_znode_count\t5\n"
+
+	tests := []struct {
+		name      string
+		responses map[string]string
+		states    []string
+		md        healthy.Metadata
+		err       string
+		fatal     bool
+	}{
+		{
+			name:      "should return nil if the server is ok",
+			responses: map[string]string{"ruok": "imok"},
+			md:        healthy.Metadata{},
+		},
+		{
+			name:      "should return an error if the server is not ok",
+			responses: map[string]string{"ruok": ""},
+			err:       "unexpected response",
+		},
+		{
+			name:      "should return a fatal error if the command is not whitelisted",
+			responses: map[string]string{"ruok": "ruok is not executed because it is not in the whitelist.\n"},
+			err:       "ruok command is not whitelisted",
+			fatal:     true,
+		},
+		{
+			name:      "should return nil if the server is a follower",
+			responses: map[string]string{"mntr": strings.Replace(mntr, "%s", "follower", 1)},
+			states:    []string{},
+			md:        healthy.Metadata{"server_state": "follower", "version": "3.9.1-abc, built on 2023-10-05 19:56 UTC"},
+		},
+		{
+			name:      "should return an error if the server is not in the required state",
+			responses: map[string]string{"mntr": strings.Replace(mntr, "%s", "follower", 1)},
+			states:    []string{"leader"},
+			err:       "server state is follower",
+		},
+		{
+			name:      "should return an error if the server is not serving requests",
+			responses: map[string]string{"mntr": "This ZooKeeper instance is not currently serving requests\n"},
+			states:    []string{},
+			err:       "server is not serving requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startFakeZooKeeper(t, tt.responses)
+
+			md := healthy.Metadata{}
+			ctx := healthy.SetContextMetadata(context.Background(), md)
+
+			c := healthy.ZooKeeper(addr).Timeout(100 * time.Millisecond)
+			if tt.states != nil {
+				c.ServerState(tt.states...)
+			}
+
+			err := c.Healthy(ctx)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("got %v, expected nil", err)
+				}
+				if !maps.Equal(md, tt.md) {
+					t.Errorf("got %v, expected %v", md, tt.md)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, expected %s", err, tt.err)
+			}
+			if act := healthy.IsFatal(err); act != tt.fatal {
+				t.Errorf("got fatal %t, expected %t", act, tt.fatal)
+			}
+		})
+	}
+}
+
+func TestZooKeeper_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		sut  *healthy.ZooKeeperCheck
+		exp  healthy.Metadata
+	}{
+		{
+			name: "should return the default metadata",
+			sut:  healthy.ZooKeeper("zk"),
+			exp:  healthy.Metadata{"type": "zookeeper", "target": "zk:2181", "timeout": "1s"},
+		},
+		{
+			name: "should return the default states",
+			sut:  healthy.ZooKeeper("zk:2182").ServerState().Timeout(2 * time.Second),
+			exp:  healthy.Metadata{"type": "zookeeper", "target": "zk:2182", "states": "leader,follower,standalone", "timeout": "2s"},
+		},
+		{
+			name: "should return the specified states",
+			sut:  healthy.ZooKeeper("zk").ServerState("leader"),
+			exp:  healthy.Metadata{"type": "zookeeper", "target": "zk:2181", "states": "leader", "timeout": "1s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := tt.sut.Metadata()
+			if !maps.Equal(act, tt.exp) {
+				t.Errorf("got %v, expected %v", act, tt.exp)
+			}
+		})
+	}
+}
+
+// startFakeZooKeeper starts a server that responds to four letter word
+// commands and closes the connection.
+func startFakeZooKeeper(t *testing.T, responses map[string]string) string {
+	return startTCPServer(t, func(conn net.Conn) {
+		cmd := make([]byte, 4)
+		if _, err := io.ReadFull(conn, cmd); err != nil {
+			return
+		}
+		io.WriteString(conn, responses[string(cmd)])
+	})
+}
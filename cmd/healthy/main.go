@@ -0,0 +1,163 @@
+// Command healthy waits for dependencies to become healthy before
+// optionally executing a command.
+//
+// Usage:
+//
+//	healthy [flags] target... [-- command [args...]]
+//
+// Targets are specified as URLs, for example tcp://db:5432,
+// http://api:8080/health or file:///tmp/ready. All targets are checked in
+// parallel and the command is executed once every target is healthy.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitTimeout
+	exitFatal
+)
+
+type config struct {
+	timeout time.Duration
+	delay   time.Duration
+	jitter  time.Duration
+	quiet   bool
+	targets []string
+	command []string
+}
+
+func main() {
+	cfg, code := parseArgs(os.Args[1:], os.Stderr)
+	if code != exitOK {
+		os.Exit(code)
+	}
+
+	if code := run(cfg, os.Stderr); code != exitOK {
+		os.Exit(code)
+	}
+
+	if len(cfg.command) > 0 {
+		if err := execCommand(cfg.command); err != nil {
+			fmt.Fprintf(os.Stderr, "healthy: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+}
+
+func parseArgs(args []string, stderr io.Writer) (config, int) {
+	var cfg config
+
+	if i := slices.Index(args, "--"); i >= 0 {
+		args, cfg.command = args[:i], args[i+1:]
+	}
+
+	fs := flag.NewFlagSet("healthy", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: healthy [flags] target... [-- command [args...]]")
+		fs.PrintDefaults()
+	}
+
+	fs.DurationVar(&cfg.timeout, "timeout", 30*time.Second, "maximum time to wait for all targets")
+	fs.DurationVar(&cfg.delay, "delay", time.Second, "delay between check attempts")
+	fs.DurationVar(&cfg.jitter, "jitter", 0, "maximum jitter to apply to the delay")
+	fs.BoolVar(&cfg.quiet, "quiet", false, "do not print progress")
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, exitUsage
+	}
+
+	cfg.targets = fs.Args()
+	if len(cfg.targets) < 1 {
+		fs.Usage()
+		return cfg, exitUsage
+	}
+
+	return cfg, exitOK
+}
+
+func run(cfg config, stderr io.Writer) int {
+	checks := make([]healthy.Check, 0, len(cfg.targets))
+	for _, t := range cfg.targets {
+		c, err := healthy.Parse(t)
+		if err != nil {
+			fmt.Fprintf(stderr, "healthy: %v\n", err)
+			return exitUsage
+		}
+		checks = append(checks, c)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mu := new(sync.Mutex)
+	opts := healthy.JoinOptions(
+		healthy.WithContext(ctx),
+		healthy.WithTimeout(cfg.timeout),
+		healthy.WithDelay(cfg.delay),
+		healthy.WithJitter(cfg.jitter),
+		healthy.WithCallback(func(ctx context.Context, err error) {
+			if cfg.quiet {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			printResult(stderr, healthy.GetContextMetadata(ctx), err)
+		}),
+	)
+
+	errs := make([]error, len(checks))
+	wg := new(sync.WaitGroup)
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = healthy.Wait(c, opts)
+			if healthy.IsFatal(errs[i]) {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return exitCode(errs)
+}
+
+func printResult(w io.Writer, md healthy.Metadata, err error) {
+	status := "ok"
+	if err != nil {
+		status = err.Error()
+	}
+	fmt.Fprintf(w, "healthy: %v %v (attempt %v): %s\n", md.Get("type"), md.Get("target"), md.Get("attempt"), status)
+}
+
+func exitCode(errs []error) int {
+	code := exitOK
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case healthy.IsFatal(err):
+			return exitFatal
+		case errors.Is(err, context.DeadlineExceeded):
+			code = exitTimeout
+		default:
+			code = max(code, exitError)
+		}
+	}
+	return code
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		exp  config
+		code int
+	}{
+		{
+			name: "should return usage error for no targets",
+			args: []string{"--timeout", "1s"},
+			code: exitUsage,
+		},
+		{
+			name: "should return usage error for invalid flags",
+			args: []string{"--timeout", "invalid", "tcp://db:5432"},
+			code: exitUsage,
+		},
+		{
+			name: "should parse the flags and targets",
+			args: []string{"--timeout", "60s", "--delay", "2s", "--jitter", "1s", "--quiet", "tcp://db:5432", "http://api:8080/health"},
+			exp: config{
+				timeout: time.Minute,
+				delay:   2 * time.Second,
+				jitter:  time.Second,
+				quiet:   true,
+				targets: []string{"tcp://db:5432", "http://api:8080/health"},
+			},
+		},
+		{
+			name: "should parse the command",
+			args: []string{"tcp://db:5432", "--", "./server", "--port", "8080"},
+			exp: config{
+				timeout: 30 * time.Second,
+				delay:   time.Second,
+				targets: []string{"tcp://db:5432"},
+				command: []string{"./server", "--port", "8080"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act, code := parseArgs(tt.args, io.Discard)
+			if code != tt.code {
+				t.Fatalf("got %d, expected %d", code, tt.code)
+			}
+			if code == exitOK && !reflect.DeepEqual(act, tt.exp) {
+				t.Errorf("got %+v, expected %+v", act, tt.exp)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	closed, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		targets []string
+		code    int
+		output  string
+	}{
+		{
+			name:    "should return usage error for invalid targets",
+			targets: []string{"gopher://host"},
+			code:    exitUsage,
+		},
+		{
+			name:    "should return ok when all targets are healthy",
+			targets: []string{"tcp://" + l.Addr().String()},
+			code:    exitOK,
+			output:  "tcp " + l.Addr().String() + " (attempt 1): ok",
+		},
+		{
+			name:    "should return timeout error",
+			targets: []string{"tcp://" + l.Addr().String(), "tcp://" + closed.Addr().String()},
+			code:    exitTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(strings.Builder)
+			cfg := config{
+				timeout: 50 * time.Millisecond,
+				delay:   10 * time.Millisecond,
+				targets: tt.targets,
+			}
+
+			if act, exp := run(cfg, out), tt.code; act != exp {
+				t.Errorf("got %d, expected %d", act, exp)
+			}
+			if !strings.Contains(out.String(), tt.output) {
+				t.Errorf("got %q, expected %q", out.String(), tt.output)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	timeout := errors.Join(context.DeadlineExceeded, errors.New("error"))
+	fatal := healthy.Fatal(errors.New("error"))
+
+	tests := []struct {
+		name string
+		errs []error
+		exp  int
+	}{
+		{name: "should return ok", errs: []error{nil, nil}, exp: exitOK},
+		{name: "should return error", errs: []error{nil, errors.New("error")}, exp: exitError},
+		{name: "should return timeout", errs: []error{errors.New("error"), timeout}, exp: exitTimeout},
+		{name: "should return fatal", errs: []error{timeout, fatal}, exp: exitFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if act := exitCode(tt.errs); act != tt.exp {
+				t.Errorf("got %d, expected %d", act, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,440 @@
+package healthy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Config represents a set of named checks and execution options.
+	Config struct {
+		Checks  []NamedCheck
+		Options []Option
+	}
+
+	// NamedCheck represents a named check with optional dependencies.
+	// The check is only executed once its dependencies are healthy.
+	NamedCheck struct {
+		Name      string
+		Check     Check
+		DependsOn []string
+	}
+
+	configDocument struct {
+		Timeout  string                `yaml:"timeout"`
+		Delay    string                `yaml:"delay"`
+		Jitter   string                `yaml:"jitter"`
+		Backoff  float64               `yaml:"backoff"`
+		MaxDelay string                `yaml:"max_delay"`
+		Checks   []configCheckDocument `yaml:"checks"`
+	}
+
+	configCheckDocument struct {
+		Name      string   `yaml:"name"`
+		URL       string   `yaml:"url"`
+		Type      string   `yaml:"type"`
+		Target    string   `yaml:"target"`
+		Expect    int      `yaml:"expect"`
+		Timeout   string   `yaml:"timeout"`
+		DependsOn []string `yaml:"depends_on"`
+	}
+
+	// ConfigError represents a configuration validation error.
+	// The path identifies the document path or environment variable.
+	ConfigError struct {
+		Path string
+		Err  error
+	}
+)
+
+// LoadConfig reads a YAML or JSON configuration document, for example:
+//
+//	timeout: 60s
+//	delay: 1s
+//	backoff: 2
+//	max_delay: 10s
+//	checks:
+//	  - name: db
+//	    type: tcp
+//	    target: db:5432
+//	  - name: api
+//	    url: http://api:8080/health?expect=204
+//	    depends_on: [db]
+//
+// Checks are specified either with a type and target or with a URL
+// supported by [Parse]. The type can be any scheme supported by [Parse],
+// with the target forming the remainder of the URL, for example a type of
+// kafka and a target of "kafka1:9092,kafka2:9092?topics=orders". The
+// target of http and https checks is the full URL. All validation errors are returned, each
+// identifying the offending path.
+func LoadConfig(r io.Reader) (*Config, error) {
+	var n yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&n); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	var doc configDocument
+	if err := decodeConfigNode(&n, reflect.ValueOf(&doc).Elem(), ""); err != nil {
+		return nil, err
+	}
+
+	return doc.build()
+}
+
+// Wait executes the checks in dependency order using the config options
+// followed by the supplied options. Independent checks are executed in
+// parallel and the timeout applies to the config as a whole.
+func (c *Config) Wait(opts ...Option) error {
+	opts = append(slices.Clone(c.Options), opts...)
+
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx, cancel := o.contextWithCancel()
+	defer cancel()
+
+	done := make(map[string]chan struct{}, len(c.Checks))
+	for _, nc := range c.Checks {
+		done[nc.Name] = make(chan struct{})
+	}
+
+	errs := make([]error, len(c.Checks))
+	wg := new(sync.WaitGroup)
+	for i, nc := range c.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[nc.Name])
+			errs[i] = c.wait(ctx, nc, done, errs, opts)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) wait(ctx context.Context, nc NamedCheck, done map[string]chan struct{}, errs []error, opts []Option) error {
+	for _, dep := range nc.DependsOn {
+		select {
+		case <-done[dep]:
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", nc.Name, context.Cause(ctx))
+		}
+
+		i := slices.IndexFunc(c.Checks, func(d NamedCheck) bool { return d.Name == dep })
+		if errs[i] != nil {
+			return fmt.Errorf("%s: dependency %s is not healthy", nc.Name, dep)
+		}
+	}
+
+	// the config context applies the timeout to all checks
+	mdctx := SetContextMetadata(ctx, Metadata{mdKeyName: nc.Name})
+	err := Wait(nc.Check, JoinOptions(opts...), WithContext(mdctx), WithTimeout(0))
+	if err != nil {
+		return fmt.Errorf("%s: %w", nc.Name, err)
+	}
+
+	return nil
+}
+
+func (d configDocument) build() (*Config, error) {
+	var errs []error
+	c := new(Config)
+
+	parseDuration := func(path, s string) (time.Duration, bool) {
+		v, err := parseConfigDuration(path, s)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return v, s != "" && err == nil
+	}
+
+	if v, ok := parseDuration("timeout", d.Timeout); ok {
+		c.Options = append(c.Options, WithTimeout(v))
+	}
+	if v, ok := parseDuration("delay", d.Delay); ok {
+		c.Options = append(c.Options, WithDelay(v))
+	}
+	if v, ok := parseDuration("jitter", d.Jitter); ok {
+		c.Options = append(c.Options, WithJitter(v))
+	}
+	maxDelay, _ := parseDuration("max_delay", d.MaxDelay)
+
+	switch {
+	case d.Backoff < 0:
+		errs = append(errs, &ConfigError{Path: "backoff", Err: errors.New("must not be negative")})
+	case d.Backoff > 0 && d.Backoff < 1:
+		errs = append(errs, &ConfigError{Path: "backoff", Err: errors.New("must be at least 1")})
+	}
+	if d.Backoff > 0 || maxDelay > 0 {
+		c.Options = append(c.Options, WithBackoff(d.Backoff, maxDelay))
+	}
+
+	names := map[string]int{}
+	for i, cd := range d.Checks {
+		path := fmt.Sprintf("checks[%d]", i)
+
+		nc, err := cd.build(path)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if _, ok := names[nc.Name]; ok {
+			errs = append(errs, &ConfigError{Path: path + ".name", Err: fmt.Errorf("duplicate name %q", nc.Name)})
+		}
+		names[nc.Name] = i
+
+		c.Checks = append(c.Checks, nc)
+	}
+
+	for i, nc := range c.Checks {
+		for j, dep := range nc.DependsOn {
+			if _, ok := names[dep]; !ok {
+				path := fmt.Sprintf("checks[%d].depends_on[%d]", i, j)
+				errs = append(errs, &ConfigError{Path: path, Err: fmt.Errorf("unknown check %q", dep)})
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		if err := c.validateCycles(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Config) validateCycles() error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := map[string]int{}
+	deps := map[string][]string{}
+	for _, nc := range c.Checks {
+		deps[nc.Name] = nc.DependsOn
+	}
+
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch state[name] {
+		case visiting:
+			return false
+		case visited:
+			return true
+		}
+
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if !visit(dep) {
+				return false
+			}
+		}
+		state[name] = visited
+		return true
+	}
+
+	for i, nc := range c.Checks {
+		if !visit(nc.Name) {
+			path := fmt.Sprintf("checks[%d].depends_on", i)
+			return &ConfigError{Path: path, Err: fmt.Errorf("dependency cycle including %q", nc.Name)}
+		}
+	}
+
+	return nil
+}
+
+func (d configCheckDocument) build(path string) (NamedCheck, error) {
+	nc := NamedCheck{
+		Name:      d.Name,
+		DependsOn: d.DependsOn,
+	}
+
+	var errs []error
+	if d.Name == "" {
+		errs = append(errs, &ConfigError{Path: path + ".name", Err: errors.New("required")})
+	}
+
+	timeout, err := parseConfigDuration(path+".timeout", d.Timeout)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	isHTTP := d.Type == "http" || d.Type == "https"
+	if d.Expect != 0 && !isHTTP {
+		errs = append(errs, &ConfigError{Path: path + ".expect", Err: errors.New("only supported for http checks")})
+	}
+
+	switch {
+	case d.URL != "" && (d.Type != "" || d.Target != ""):
+		errs = append(errs, &ConfigError{Path: path + ".url", Err: errors.New("must not be specified with type or target")})
+
+	case d.URL != "":
+		c, err := Parse(d.URL)
+		if err != nil {
+			errs = append(errs, &ConfigError{Path: path + ".url", Err: err})
+		}
+		nc.Check = c
+
+	case d.Target == "":
+		errs = append(errs, &ConfigError{Path: path + ".target", Err: errors.New("required")})
+
+	case isHTTP:
+		c := HTTP(d.Target)
+		if timeout > 0 {
+			c.Timeout(timeout)
+		}
+		if d.Expect > 0 {
+			c.Expect(d.Expect)
+		}
+		nc.Check = c
+
+	case !isRegisteredScheme(d.Type):
+		errs = append(errs, &ConfigError{Path: path + ".type", Err: fmt.Errorf("unsupported type %q", d.Type)})
+
+	default:
+		// other types are parsed as a url with the scheme registry
+		c, err := parseConfigCheck(d.Type, d.Target, timeout)
+		if err != nil {
+			errs = append(errs, &ConfigError{Path: path + ".target", Err: err})
+		}
+		nc.Check = c
+	}
+
+	return nc, errors.Join(errs...)
+}
+
+// decodeConfigNode decodes the node into the value, returning errors that
+// identify the document path rather than the line number.
+func decodeConfigNode(n *yaml.Node, v reflect.Value, path string) error {
+	switch {
+	case n.Kind == yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return decodeConfigNode(n.Content[0], v, path)
+	case n.Kind == yaml.AliasNode:
+		return decodeConfigNode(n.Alias, v, path)
+	case n.Kind == 0, n.Tag == "!!null":
+		return nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			if path == "" {
+				return errors.New("invalid config: must be a mapping")
+			}
+			return &ConfigError{Path: path, Err: errors.New("must be a mapping")}
+		}
+
+		var errs []error
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+
+			f, ok := configField(v, key)
+			if !ok {
+				errs = append(errs, &ConfigError{Path: p, Err: errors.New("unknown field")})
+				continue
+			}
+			errs = append(errs, decodeConfigNode(n.Content[i+1], f, p))
+		}
+		return errors.Join(errs...)
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Struct:
+		if n.Kind != yaml.SequenceNode {
+			return &ConfigError{Path: path, Err: errors.New("must be a sequence")}
+		}
+
+		v.Set(reflect.MakeSlice(v.Type(), len(n.Content), len(n.Content)))
+
+		var errs []error
+		for i, item := range n.Content {
+			errs = append(errs, decodeConfigNode(item, v.Index(i), fmt.Sprintf("%s[%d]", path, i)))
+		}
+		return errors.Join(errs...)
+
+	default:
+		if err := n.Decode(v.Addr().Interface()); err != nil {
+			// type errors are prefixed with the line number
+			msg := err.Error()
+			var terr *yaml.TypeError
+			if errors.As(err, &terr) && len(terr.Errors) > 0 {
+				msg = terr.Errors[0]
+				if _, m, ok := strings.Cut(msg, ": "); ok && strings.HasPrefix(msg, "line ") {
+					msg = m
+				}
+			}
+			return &ConfigError{Path: path, Err: errors.New(msg)}
+		}
+		return nil
+	}
+}
+
+func configField(v reflect.Value, key string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func parseConfigCheck(typ, target string, timeout time.Duration) (Check, error) {
+	u, err := parseURL(typ + "://" + target)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		q := u.Query()
+		q.Set("timeout", timeout.String())
+		u.RawQuery = q.Encode()
+	}
+
+	return Parse(u.String())
+}
+
+func parseConfigDuration(path, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, &ConfigError{Path: path, Err: fmt.Errorf("invalid duration %q", s)}
+	}
+
+	return d, nil
+}
+
+// Error returns the path and error message.
+func (e *ConfigError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the inner error.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
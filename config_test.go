@@ -0,0 +1,279 @@
+package healthy_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		checks []string
+		errs   []string
+	}{
+		{
+			name: "should return an error for invalid documents",
+			doc:  "checks: [",
+			errs: []string{"invalid config"},
+		},
+		{
+			name: "should return an error for non-mapping documents",
+			doc:  "- tcp://db:5432",
+			errs: []string{"invalid config: must be a mapping"},
+		},
+		{
+			name: "should return an error for unknown fields",
+			doc: `
+unknown: true
+checks:
+  - name: db
+    other: 1
+`,
+			errs: []string{"unknown: unknown field", "checks[0].other: unknown field"},
+		},
+		{
+			name: "should return an error for invalid field types",
+			doc:  `{"backoff": "x", "checks": [{"name": "db", "expect": "y"}]}`,
+			errs: []string{"backoff: cannot unmarshal", "checks[0].expect: cannot unmarshal"},
+		},
+		{
+			name: "should return all validation errors",
+			doc: `
+timeout: invalid
+backoff: -1
+checks:
+  - type: tcp
+    target: db:5432
+    timeout: 2x
+  - name: api
+    type: gopher
+    target: host
+  - name: api
+    url: gopher://host
+  - name: web
+    type: tcp
+    target: host:80
+    expect: 200
+    depends_on: [unknown]
+`,
+			errs: []string{
+				`timeout: invalid duration "invalid"`,
+				"backoff: must not be negative",
+				"checks[0].name: required",
+				`checks[0].timeout: invalid duration "2x"`,
+				`checks[1].type: unsupported type "gopher"`,
+				"checks[2].url: invalid check",
+				`checks[2].name: duplicate name "api"`,
+				"checks[3].expect: only supported for http checks",
+				`checks[3].depends_on[0]: unknown check "unknown"`,
+			},
+		},
+		{
+			name: "should return an error for dependency cycles",
+			doc: `
+checks:
+  - name: a
+    url: tcp://a:1
+    depends_on: [b]
+  - name: b
+    url: tcp://b:1
+    depends_on: [a]
+`,
+			errs: []string{`checks[0].depends_on: dependency cycle including "a"`},
+		},
+		{
+			name: "should return an error for invalid targets",
+			doc: `
+checks:
+  - name: kafka
+    type: kafka
+    target: kafka:9092?other=1
+`,
+			errs: []string{"checks[0].target: invalid check"},
+		},
+		{
+			name: "should return an error for backoff factors less than one",
+			doc:  "backoff: 0.5",
+			errs: []string{"backoff: must be at least 1"},
+		},
+		{
+			name: "should load yaml documents",
+			doc: `
+timeout: 60s
+delay: 2s
+jitter: 100ms
+backoff: 2
+max_delay: 10s
+checks:
+  - name: db
+    type: tcp
+    target: db:5432
+    timeout: 2s
+  - name: api
+    type: http
+    target: http://api:8080/health
+    expect: 204
+    depends_on: [db]
+  - name: ready
+    url: file:///tmp/ready
+  - name: kafka
+    type: kafka
+    target: kafka1:9092,kafka2:9092?topics=orders
+    timeout: 2s
+`,
+			checks: []string{"db", "api", "ready", "kafka"},
+		},
+		{
+			name:   "should load json documents",
+			doc:    `{"timeout": "60s", "checks": [{"name": "db", "url": "tcp://db:5432"}]}`,
+			checks: []string{"db"},
+		},
+		{
+			name: "should load empty documents",
+			doc:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := healthy.LoadConfig(strings.NewReader(tt.doc))
+			if len(tt.errs) > 0 {
+				if err == nil {
+					t.Fatal("got nil, expected error")
+				}
+				for _, exp := range tt.errs {
+					if !strings.Contains(err.Error(), exp) {
+						t.Errorf("got %v, expected %s", err, exp)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %v, expected nil", err)
+			}
+
+			if act, exp := len(c.Checks), len(tt.checks); act != exp {
+				t.Fatalf("got %d checks, expected %d", act, exp)
+			}
+			for i, exp := range tt.checks {
+				if act := c.Checks[i].Name; act != exp {
+					t.Errorf("got %s, expected %s", act, exp)
+				}
+			}
+		})
+	}
+
+	t.Run("should return config errors", func(t *testing.T) {
+		_, err := healthy.LoadConfig(strings.NewReader("delay: x"))
+
+		var cerr *healthy.ConfigError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("got %v, expected config error", err)
+		}
+		if act, exp := cerr.Path, "delay"; act != exp {
+			t.Errorf("got %s, expected %s", act, exp)
+		}
+	})
+}
+
+func TestConfig_Wait(t *testing.T) {
+	t.Run("should execute checks in dependency order", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var order []string
+			mu := new(sync.Mutex)
+
+			check := func(name string, delay time.Duration) healthy.Check {
+				return healthy.CheckFunc(func(ctx context.Context) error {
+					time.Sleep(delay)
+					mu.Lock()
+					defer mu.Unlock()
+					order = append(order, name)
+					return nil
+				})
+			}
+
+			c := &healthy.Config{
+				Checks: []healthy.NamedCheck{
+					{Name: "api", Check: check("api", 0), DependsOn: []string{"db", "cache"}},
+					{Name: "db", Check: check("db", 2*time.Second)},
+					{Name: "cache", Check: check("cache", time.Second)},
+				},
+			}
+
+			if err := c.Wait(); err != nil {
+				t.Fatalf("got %v, expected nil", err)
+			}
+			if act, exp := strings.Join(order, ","), "cache,db,api"; act != exp {
+				t.Errorf("got %s, expected %s", act, exp)
+			}
+		})
+	})
+
+	t.Run("should not execute checks with failed dependencies", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var executed bool
+			c := &healthy.Config{
+				Checks: []healthy.NamedCheck{
+					{Name: "api", Check: healthy.CheckFunc(func(ctx context.Context) error {
+						executed = true
+						return nil
+					}), DependsOn: []string{"db"}},
+					{Name: "db", Check: healthy.CheckFunc(func(ctx context.Context) error {
+						return healthy.Fatal(errors.New("error"))
+					})},
+				},
+			}
+
+			err := c.Wait()
+			if !healthy.IsFatal(err) {
+				t.Errorf("got %v, expected fatal error", err)
+			}
+			if !strings.Contains(err.Error(), "api: dependency db is not healthy") {
+				t.Errorf("got %v, expected dependency error", err)
+			}
+			if executed {
+				t.Error("got true, expected false")
+			}
+		})
+	})
+
+	t.Run("should apply the timeout to all checks", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			c, err := healthy.LoadConfig(strings.NewReader(`
+timeout: 5s
+checks:
+  - name: a
+    url: tcp://a:1
+`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.Checks[0].Check = healthy.CheckFunc(func(ctx context.Context) error {
+				if _, ok := ctx.Deadline(); !ok {
+					return healthy.Fatal(errors.New("no deadline"))
+				}
+				if act, exp := healthy.GetContextMetadata(ctx).Get("name"), "a"; act != exp {
+					return healthy.Fatal(errors.New("no name"))
+				}
+				return errors.New("error")
+			})
+
+			start := time.Now()
+			err = c.Wait()
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+			}
+			if act, exp := time.Since(start), 5*time.Second; act != exp {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+		})
+	})
+}
@@ -0,0 +1,105 @@
+package healthy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromEnv builds a [Config] from environment variables with the supplied
+// prefix, for example:
+//
+//	HEALTHY_TIMEOUT=60s
+//	HEALTHY_DELAY=1s
+//	HEALTHY_JITTER=500ms
+//	HEALTHY_BACKOFF=2
+//	HEALTHY_MAX_DELAY=10s
+//	HEALTHY_CHECK_DB=tcp://db:5432
+//
+// Check variables are parsed using [Parse] and named using the lowercase
+// variable suffix. Empty values and other variables with the prefix are
+// ignored, allowing the prefix to be shared with application settings. All
+// invalid values are reported in the returned error.
+func FromEnv(prefix string) (*Config, error) {
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+	checkPrefix := prefix + "CHECK_"
+
+	var errs []error
+	c := new(Config)
+
+	env := os.Environ()
+	slices.Sort(env)
+
+	var backoff float64
+	var maxDelay time.Duration
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(k, prefix) || v == "" {
+			continue
+		}
+
+		switch k {
+		case prefix + "TIMEOUT":
+			d, err := parseConfigDuration(k, v)
+			errs = append(errs, err)
+			c.Options = append(c.Options, WithTimeout(d))
+
+		case prefix + "DELAY":
+			d, err := parseConfigDuration(k, v)
+			errs = append(errs, err)
+			c.Options = append(c.Options, WithDelay(d))
+
+		case prefix + "JITTER":
+			d, err := parseConfigDuration(k, v)
+			errs = append(errs, err)
+			c.Options = append(c.Options, WithJitter(d))
+
+		case prefix + "BACKOFF":
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil || f < 0 || (f > 0 && f < 1) {
+				errs = append(errs, &ConfigError{Path: k, Err: fmt.Errorf("invalid backoff %q", v)})
+			}
+			backoff = f
+
+		case prefix + "MAX_DELAY":
+			d, err := parseConfigDuration(k, v)
+			errs = append(errs, err)
+			maxDelay = d
+
+		default:
+			name, ok := strings.CutPrefix(k, checkPrefix)
+			if !ok {
+				continue
+			}
+			if name == "" {
+				errs = append(errs, &ConfigError{Path: k, Err: errors.New("missing check name")})
+				continue
+			}
+
+			ch, err := Parse(v)
+			if err != nil {
+				errs = append(errs, &ConfigError{Path: k, Err: err})
+				continue
+			}
+
+			c.Checks = append(c.Checks, NamedCheck{
+				Name:  strings.ToLower(name),
+				Check: ch,
+			})
+		}
+	}
+
+	if backoff > 0 || maxDelay > 0 {
+		c.Options = append(c.Options, WithBackoff(backoff, maxDelay))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,113 @@
+package healthy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		env    map[string]string
+		checks []string
+		opts   int
+		errs   []string
+	}{
+		{
+			name:   "should return an empty config",
+			prefix: "TEST",
+		},
+		{
+			name:   "should build the config",
+			prefix: "TEST_",
+			env: map[string]string{
+				"TEST_TIMEOUT":   "60s",
+				"TEST_DELAY":     "2s",
+				"TEST_JITTER":    "100ms",
+				"TEST_BACKOFF":   "2",
+				"TEST_MAX_DELAY": "10s",
+				"TEST_CHECK_DB":  "tcp://db:5432",
+				"TEST_CHECK_API": "http://api:8080/health",
+				"OTHER_TIMEOUT":  "invalid",
+			},
+			checks: []string{"api", "db"},
+			opts:   4,
+		},
+		{
+			name:   "should ignore empty values",
+			prefix: "TEST",
+			env: map[string]string{
+				"TEST_TIMEOUT":  "",
+				"TEST_DELAY":    "",
+				"TEST_BACKOFF":  "",
+				"TEST_CHECK_DB": "",
+			},
+		},
+		{
+			name:   "should ignore unknown variables",
+			prefix: "TEST",
+			env: map[string]string{
+				"TEST_PORT":     "8080",
+				"TEST_CHECK_DB": "tcp://db:5432",
+			},
+			checks: []string{"db"},
+		},
+		{
+			name:   "should return all errors",
+			prefix: "TEST",
+			env: map[string]string{
+				"TEST_TIMEOUT":  "invalid",
+				"TEST_DELAY":    "2x",
+				"TEST_BACKOFF":  "0.5",
+				"TEST_CHECK_DB": "gopher://db",
+				"TEST_CHECK_":   "tcp://db:5432",
+			},
+			errs: []string{
+				`TEST_TIMEOUT: invalid duration "invalid"`,
+				`TEST_DELAY: invalid duration "2x"`,
+				`TEST_BACKOFF: invalid backoff "0.5"`,
+				"TEST_CHECK_DB: invalid check",
+				"TEST_CHECK_: missing check name",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			c, err := healthy.FromEnv(tt.prefix)
+			if len(tt.errs) > 0 {
+				if err == nil {
+					t.Fatal("got nil, expected error")
+				}
+				for _, exp := range tt.errs {
+					if !strings.Contains(err.Error(), exp) {
+						t.Errorf("got %v, expected %s", err, exp)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got %v, expected nil", err)
+			}
+
+			if act, exp := len(c.Checks), len(tt.checks); act != exp {
+				t.Fatalf("got %d checks, expected %d", act, exp)
+			}
+			for i, exp := range tt.checks {
+				if act := c.Checks[i].Name; act != exp {
+					t.Errorf("got %s, expected %s", act, exp)
+				}
+			}
+			if act, exp := len(c.Options), tt.opts; act != exp {
+				t.Errorf("got %d options, expected %d", act, exp)
+			}
+		})
+	}
+}
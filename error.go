@@ -27,3 +27,29 @@ func (e *fatalError) Error() string {
 func (e *fatalError) Unwrap() error {
 	return e.err
 }
+
+type warnError struct {
+	err error
+}
+
+// Warn wraps the supplied error to indicate a degraded but usable state.
+// By default [Wait] treats warnings as success.
+func Warn(err error) error {
+	return &warnError{err: err}
+}
+
+// IsWarn returns true if the supplied error is a warning.
+func IsWarn(err error) bool {
+	var w *warnError
+	return errors.As(err, &w)
+}
+
+// Error returns the inner error message.
+func (e *warnError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the inner error.
+func (e *warnError) Unwrap() error {
+	return e.err
+}
@@ -35,3 +35,35 @@ func TestFatalError_Unwrap(t *testing.T) {
 		}
 	})
 }
+
+func TestWarn(t *testing.T) {
+	t.Run("should return a warning", func(t *testing.T) {
+		err := healthy.Warn(errors.New("error"))
+		if !healthy.IsWarn(err) {
+			t.Errorf("got %v, expected warning", err)
+		}
+		if healthy.IsFatal(err) {
+			t.Errorf("got %v, expected non-fatal error", err)
+		}
+	})
+}
+
+func TestWarnError_Error(t *testing.T) {
+	t.Run("should return the inner error", func(t *testing.T) {
+		const exp = "error"
+		act := healthy.Warn(errors.New(exp)).Error()
+		if act != exp {
+			t.Errorf("got %s, expected %s", act, exp)
+		}
+	})
+}
+
+func TestWarnError_Unwrap(t *testing.T) {
+	t.Run("should return the inner error", func(t *testing.T) {
+		exp := errors.New("error")
+		act := healthy.Warn(exp).(interface{ Unwrap() error }).Unwrap()
+		if act != exp {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
@@ -1,5 +1,7 @@
 module github.com/stevecallear/healthy
 
-go 1.23.0
+go 1.25.0
 
 toolchain go1.25.1
+
+require gopkg.in/yaml.v3 v3.0.1
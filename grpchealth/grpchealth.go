@@ -0,0 +1,158 @@
+// Package grpchealth provides a gRPC health server backed by healthy checks.
+package grpchealth
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/stevecallear/healthy"
+)
+
+// Server represents a grpc.health.v1.Health server.
+// Service status is driven by the results of the checks registered with
+// the supplied monitor.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	monitor  *healthy.Monitor
+	mu       sync.RWMutex
+	services map[string][]string
+}
+
+var _ healthpb.HealthServer = (*Server)(nil)
+
+// NewServer returns a new health server for the supplied monitor.
+// By default each monitor check is exposed as a service of the same name
+// and the empty service name reports the status of all checks.
+func NewServer(m *healthy.Monitor) *Server {
+	return &Server{
+		monitor:  m,
+		services: map[string][]string{},
+	}
+}
+
+// Service maps the service name to the named monitor checks.
+// The service is serving only if none of the checks have failed. Checks
+// reporting a warning are considered serving.
+func (s *Server) Service(name string, checks ...string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services[name] = checks
+	return s
+}
+
+// Register registers the server with the supplied gRPC server.
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(r, s)
+}
+
+// Check returns the current status of the requested service.
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, ok := s.status(req.GetService())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// List returns the current status of all services.
+func (s *Server) List(ctx context.Context, req *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	res := &healthpb.HealthListResponse{
+		Statuses: map[string]*healthpb.HealthCheckResponse{},
+	}
+	for _, name := range s.serviceNames() {
+		st, _ := s.status(name)
+		res.Statuses[name] = &healthpb.HealthCheckResponse{Status: st}
+	}
+	return res, nil
+}
+
+// Watch streams the status of the requested service.
+// The current status is sent immediately, followed by any status changes.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	changed := make(chan struct{}, 1)
+	unsubscribe := s.monitor.Subscribe(func(string, healthy.Result) {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, ok := s.status(req.GetService())
+		if !ok {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return status.Error(codes.Canceled, "stream has ended")
+			}
+			last = st
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		}
+	}
+}
+
+func (s *Server) serviceNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.services) > 0 {
+		return slices.Sorted(maps.Keys(s.services))
+	}
+	return append([]string{""}, s.monitor.Names()...)
+}
+
+func (s *Server) checkNames(service string) ([]string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.services) > 0 {
+		checks, ok := s.services[service]
+		return checks, ok
+	}
+
+	names := s.monitor.Names()
+	if service == "" {
+		return names, true
+	}
+	if slices.Contains(names, service) {
+		return []string{service}, true
+	}
+	return nil, false
+}
+
+func (s *Server) status(service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	names, ok := s.checkNames(service)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+
+	st := healthpb.HealthCheckResponse_SERVING
+	for _, name := range names {
+		r, ok := s.monitor.Result(name)
+		if !ok {
+			return healthpb.HealthCheckResponse_UNKNOWN, true
+		}
+		if r.Status() == healthy.HealthFail {
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	return st, true
+}
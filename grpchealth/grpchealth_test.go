@@ -0,0 +1,212 @@
+package grpchealth_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stevecallear/healthy"
+	"github.com/stevecallear/healthy/grpchealth"
+)
+
+func TestServer_Check(t *testing.T) {
+	m := healthy.NewMonitor().
+		Interval(10*time.Millisecond).
+		Add("pass", healthy.CheckFunc(func(ctx context.Context) error { return nil })).
+		Add("warn", healthy.CheckFunc(func(ctx context.Context) error { return healthy.Warn(errors.New("warning")) })).
+		Add("fail", healthy.CheckFunc(func(ctx context.Context) error { return errors.New("error") }))
+
+	tests := []struct {
+		name    string
+		sut     *grpchealth.Server
+		service string
+		exp     healthpb.HealthCheckResponse_ServingStatus
+		code    codes.Code
+	}{
+		{
+			name:    "should return serving for a healthy check",
+			sut:     grpchealth.NewServer(m),
+			service: "pass",
+			exp:     healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:    "should return serving for a check with a warning",
+			sut:     grpchealth.NewServer(m),
+			service: "warn",
+			exp:     healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:    "should return not serving for an unhealthy check",
+			sut:     grpchealth.NewServer(m),
+			service: "fail",
+			exp:     healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:    "should return not serving for the server if any check is unhealthy",
+			sut:     grpchealth.NewServer(m),
+			service: "",
+			exp:     healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:    "should return not found for an unknown service",
+			sut:     grpchealth.NewServer(m),
+			service: "unknown",
+			code:    codes.NotFound,
+		},
+		{
+			name:    "should return the mapped service status",
+			sut:     grpchealth.NewServer(m).Service("svc", "pass"),
+			service: "svc",
+			exp:     healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:    "should return not found for unmapped services",
+			sut:     grpchealth.NewServer(m).Service("svc", "pass"),
+			service: "pass",
+			code:    codes.NotFound,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Run(ctx)
+	waitForResults(t, m, 3)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, tt.sut)
+
+			res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+			if act, exp := status.Code(err), tt.code; act != exp {
+				t.Fatalf("got %v, expected %v", act, exp)
+			}
+			if act, exp := res.GetStatus(), tt.exp; err == nil && act != exp {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+		})
+	}
+}
+
+func TestServer_List(t *testing.T) {
+	t.Run("should return all service statuses", func(t *testing.T) {
+		m := healthy.NewMonitor().
+			Add("pass", healthy.CheckFunc(func(ctx context.Context) error { return nil }))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go m.Run(ctx)
+		waitForResults(t, m, 1)
+
+		client := newClient(t, grpchealth.NewServer(m))
+		res, err := client.List(context.Background(), &healthpb.HealthListRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range []string{"", "pass"} {
+			if act, exp := res.GetStatuses()[name].GetStatus(), healthpb.HealthCheckResponse_SERVING; act != exp {
+				t.Errorf("got %v for %q, expected %v", act, name, exp)
+			}
+		}
+	})
+}
+
+func TestServer_Watch(t *testing.T) {
+	t.Run("should stream status changes", func(t *testing.T) {
+		var up atomic.Bool
+		m := healthy.NewMonitor().
+			Interval(10*time.Millisecond).
+			Add("a", healthy.CheckFunc(func(ctx context.Context) error {
+				if up.Load() {
+					return nil
+				}
+				return errors.New("error")
+			}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go m.Run(ctx)
+		waitForResults(t, m, 1)
+
+		client := newClient(t, grpchealth.NewServer(m))
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act, exp := res.GetStatus(), healthpb.HealthCheckResponse_NOT_SERVING; act != exp {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+
+		up.Store(true)
+
+		res, err = stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act, exp := res.GetStatus(), healthpb.HealthCheckResponse_SERVING; act != exp {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+
+	t.Run("should return service unknown for an unknown service", func(t *testing.T) {
+		client := newClient(t, grpchealth.NewServer(healthy.NewMonitor()))
+		stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if act, exp := res.GetStatus(), healthpb.HealthCheckResponse_SERVICE_UNKNOWN; act != exp {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+	})
+}
+
+func newClient(t *testing.T, s *grpchealth.Server) healthpb.HealthClient {
+	l := bufconn.Listen(1024 * 1024)
+
+	gs := grpc.NewServer()
+	s.Register(gs)
+	go gs.Serve(l)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func waitForResults(t *testing.T, m *healthy.Monitor, n int) {
+	deadline := time.Now().Add(time.Second)
+	for len(m.Results()) < n {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for results")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,159 @@
+package healthy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// HealthResponse represents an application/health+json response.
+	// See https://datatracker.ietf.org/doc/html/draft-inadarei-api-health-check
+	HealthResponse struct {
+		Status      HealthStatus             `json:"status"`
+		Version     string                   `json:"version,omitempty"`
+		ReleaseID   string                   `json:"releaseId,omitempty"`
+		ServiceID   string                   `json:"serviceId,omitempty"`
+		Description string                   `json:"description,omitempty"`
+		Output      string                   `json:"output,omitempty"`
+		Checks      map[string][]HealthCheck `json:"checks,omitempty"`
+	}
+
+	// HealthCheck represents a single check entry in a [HealthResponse].
+	HealthCheck struct {
+		ComponentID   string       `json:"componentId,omitempty"`
+		ComponentType string       `json:"componentType,omitempty"`
+		ObservedValue any          `json:"observedValue,omitempty"`
+		ObservedUnit  string       `json:"observedUnit,omitempty"`
+		Status        HealthStatus `json:"status"`
+		Time          string       `json:"time,omitempty"`
+		Output        string       `json:"output,omitempty"`
+	}
+
+	// HealthStatus represents an application/health+json status.
+	HealthStatus string
+)
+
+// HealthContentType is the application/health+json media type.
+const HealthContentType = "application/health+json"
+
+const (
+	// HealthPass indicates a healthy status.
+	HealthPass HealthStatus = "pass"
+
+	// HealthWarn indicates a healthy status with some concerns.
+	HealthWarn HealthStatus = "warn"
+
+	// HealthFail indicates an unhealthy status.
+	HealthFail HealthStatus = "fail"
+)
+
+const measurementResponseTime = "responseTime"
+
+// NewHealthResponse returns a [HealthResponse] for the supplied results.
+// Checks are keyed by the "name" metadata value, falling back to "type",
+// and report the attempt duration as the responseTime measurement.
+func NewHealthResponse(results ...Result) *HealthResponse {
+	r := &HealthResponse{
+		Status: HealthPass,
+		Checks: map[string][]HealthCheck{},
+	}
+
+	for _, res := range results {
+		c := newHealthCheck(res)
+		r.Status = worstHealthStatus(r.Status, c.Status)
+
+		key := healthComponentName(res.Metadata) + ":" + measurementResponseTime
+		r.Checks[key] = append(r.Checks[key], c)
+	}
+
+	return r
+}
+
+// StatusCode returns the HTTP status code for the response.
+func (r *HealthResponse) StatusCode() int {
+	if r.Status == HealthFail {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// ServeHTTP writes the response as application/health+json.
+func (r *HealthResponse) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", HealthContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(r.StatusCode())
+	json.NewEncoder(w).Encode(r)
+}
+
+// HealthHandler returns an HTTP handler that executes the checks in parallel
+// on each request and writes the results as application/health+json.
+func HealthHandler(checks ...Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewHealthResponse(runAll(r.Context(), checks)...).ServeHTTP(w, r)
+	})
+}
+
+func runAll(ctx context.Context, checks []Check) []Result {
+	results := make([]Result, len(checks))
+
+	wg := new(sync.WaitGroup)
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = Run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func newHealthCheck(res Result) HealthCheck {
+	c := HealthCheck{
+		ComponentID:   metadataString(res.Metadata, "target"),
+		ComponentType: metadataString(res.Metadata, "type"),
+		ObservedValue: float64(res.Duration) / float64(time.Millisecond),
+		ObservedUnit:  "ms",
+		Status:        res.Status(),
+	}
+	if !res.Time.IsZero() {
+		c.Time = res.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if res.Err != nil {
+		c.Output = res.Err.Error()
+	}
+	return c
+}
+
+func healthComponentName(md Metadata) string {
+	for _, k := range []string{"name", "type"} {
+		if s := metadataString(md, k); s != "" {
+			return s
+		}
+	}
+	return "check"
+}
+
+func worstHealthStatus(a, b HealthStatus) HealthStatus {
+	rank := map[HealthStatus]int{HealthPass: 0, HealthWarn: 1, HealthFail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+func metadataString(md Metadata, key string) string {
+	switch v := md.Get(key).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,171 @@
+package healthy_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestNewHealthResponse(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		results []healthy.Result
+		exp     *healthy.HealthResponse
+	}{
+		{
+			name: "should return pass for no results",
+			exp: &healthy.HealthResponse{
+				Status: healthy.HealthPass,
+				Checks: map[string][]healthy.HealthCheck{},
+			},
+		},
+		{
+			name: "should return warn for warnings",
+			results: []healthy.Result{
+				{
+					Metadata: healthy.Metadata{"type": "tcp"},
+					Err:      healthy.Warn(errors.New("warning")),
+					Duration: time.Millisecond,
+				},
+			},
+			exp: &healthy.HealthResponse{
+				Status: healthy.HealthWarn,
+				Checks: map[string][]healthy.HealthCheck{
+					"tcp:responseTime": {{
+						ComponentType: "tcp",
+						ObservedValue: 1.0,
+						ObservedUnit:  "ms",
+						Status:        healthy.HealthWarn,
+						Output:        "warning",
+					}},
+				},
+			},
+		},
+		{
+			name: "should map the results",
+			results: []healthy.Result{
+				{
+					Metadata: healthy.Metadata{"type": "tcp", "target": "db:5432", "name": "db"},
+					Time:     now,
+					Duration: 1500 * time.Microsecond,
+				},
+				{
+					Metadata: healthy.Metadata{"type": "http", "target": "http://api"},
+					Err:      errors.New("error"),
+					Time:     now,
+					Duration: 2 * time.Millisecond,
+				},
+				{
+					Metadata: healthy.Metadata{"type": "http", "target": "http://other"},
+					Time:     now,
+					Duration: 3 * time.Millisecond,
+				},
+			},
+			exp: &healthy.HealthResponse{
+				Status: healthy.HealthFail,
+				Checks: map[string][]healthy.HealthCheck{
+					"db:responseTime": {{
+						ComponentID:   "db:5432",
+						ComponentType: "tcp",
+						ObservedValue: 1.5,
+						ObservedUnit:  "ms",
+						Status:        healthy.HealthPass,
+						Time:          "2025-01-02T03:04:05Z",
+					}},
+					"http:responseTime": {
+						{
+							ComponentID:   "http://api",
+							ComponentType: "http",
+							ObservedValue: 2.0,
+							ObservedUnit:  "ms",
+							Status:        healthy.HealthFail,
+							Time:          "2025-01-02T03:04:05Z",
+							Output:        "error",
+						},
+						{
+							ComponentID:   "http://other",
+							ComponentType: "http",
+							ObservedValue: 3.0,
+							ObservedUnit:  "ms",
+							Status:        healthy.HealthPass,
+							Time:          "2025-01-02T03:04:05Z",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := healthy.NewHealthResponse(tt.results...)
+			if !reflect.DeepEqual(act, tt.exp) {
+				t.Errorf("got %+v, expected %+v", act, tt.exp)
+			}
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	pass := healthy.WithMetadata(func(ctx context.Context) error {
+		return nil
+	}, "name", "pass")
+
+	fail := healthy.WithMetadata(func(ctx context.Context) error {
+		return errors.New("error")
+	}, "name", "fail")
+
+	tests := []struct {
+		name   string
+		checks []healthy.Check
+		code   int
+		status healthy.HealthStatus
+	}{
+		{
+			name:   "should return ok on pass",
+			checks: []healthy.Check{pass},
+			code:   http.StatusOK,
+			status: healthy.HealthPass,
+		},
+		{
+			name:   "should return service unavailable on fail",
+			checks: []healthy.Check{pass, fail},
+			code:   http.StatusServiceUnavailable,
+			status: healthy.HealthFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			healthy.HealthHandler(tt.checks...).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if act, exp := rec.Code, tt.code; act != exp {
+				t.Errorf("got %d, expected %d", act, exp)
+			}
+			if act, exp := rec.Header().Get("Content-Type"), healthy.HealthContentType; act != exp {
+				t.Errorf("got %s, expected %s", act, exp)
+			}
+
+			var res healthy.HealthResponse
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if act, exp := res.Status, tt.status; act != exp {
+				t.Errorf("got %s, expected %s", act, exp)
+			}
+			if act, exp := len(res.Checks), len(tt.checks); act != exp {
+				t.Errorf("got %d checks, expected %d", act, exp)
+			}
+		})
+	}
+}
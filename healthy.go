@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"maps"
+	"math"
 	"math/rand/v2"
 	"strconv"
 	"time"
@@ -18,7 +19,10 @@ type (
 		timeout  time.Duration
 		delay    time.Duration
 		jitter   time.Duration
+		backoff  float64
+		maxDelay time.Duration
 		callback CallbackFunc
+		strict   bool
 	}
 
 	// CallbackFunc represents an execution callback function.
@@ -65,12 +69,12 @@ func Wait(c Check, opts ...Option) error {
 		if IsFatal(err) {
 			return err
 		}
-		if err == nil {
+		if err == nil || (IsWarn(err) && !o.strict) {
 			return nil
 		}
 
 		select {
-		case <-time.After(o.calculateDelay()):
+		case <-time.After(o.calculateDelay(attempt)):
 		case <-ctx.Done():
 			return errors.Join(context.Cause(ctx), err)
 		}
@@ -121,6 +125,17 @@ func WithJitter(j time.Duration) Option {
 	}
 }
 
+// WithBackoff specifies an exponential backoff factor to apply to the delay
+// after each attempt. The delay will not exceed the maximum if it is
+// greater than zero. Factors less than one are ignored. The default value is
+// no backoff.
+func WithBackoff(factor float64, max time.Duration) Option {
+	return func(o *options) {
+		o.backoff = factor
+		o.maxDelay = max
+	}
+}
+
 // WithCallback specifies the callback function to be invoked after check execution.
 func WithCallback(fn CallbackFunc) Option {
 	return func(o *options) {
@@ -128,6 +143,14 @@ func WithCallback(fn CallbackFunc) Option {
 	}
 }
 
+// WithWarnAsFailure specifies that warnings should be treated as failures.
+// The default behaviour is to treat warnings as success.
+func WithWarnAsFailure() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
 func (o options) contextWithCancel() (context.Context, func()) {
 	ctx := o.ctx
 	cancel := func() {}
@@ -142,11 +165,22 @@ func (o options) contextWithCancel() (context.Context, func()) {
 	return ctx, cancel
 }
 
-func (o options) calculateDelay() time.Duration {
+func (o options) calculateDelay(attempt int) time.Duration {
+	d := o.delay
+	if o.backoff > 1 {
+		d = math.MaxInt64
+		if f := float64(o.delay) * math.Pow(o.backoff, float64(attempt-1)); f < math.MaxInt64 {
+			d = time.Duration(f)
+		}
+	}
+	if o.maxDelay > 0 && d > o.maxDelay {
+		d = o.maxDelay
+	}
+
 	if o.jitter < 1 {
-		return o.delay
+		return d
 	}
 
 	j := rand.Int64N(int64(o.jitter))
-	return o.delay + time.Duration(j)
+	return d + time.Duration(j)
 }
@@ -3,6 +3,7 @@ package healthy_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -64,6 +65,75 @@ func TestWait(t *testing.T) {
 	})
 }
 
+func TestWithWarnAsFailure(t *testing.T) {
+	check := healthy.CheckFunc(func(ctx context.Context) error {
+		return healthy.Warn(errors.New("warning"))
+	})
+
+	t.Run("should treat warnings as success by default", func(t *testing.T) {
+		err := healthy.Wait(check)
+		if err != nil {
+			t.Errorf("got %v, expected nil", err)
+		}
+	})
+
+	t.Run("should treat warnings as failure", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			err := healthy.Wait(check, healthy.WithWarnAsFailure())
+			if !healthy.IsWarn(err) || !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got %v, expected warning and %v", err, context.DeadlineExceeded)
+			}
+		})
+	})
+}
+
+func TestWithBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor float64
+		max    time.Duration
+		exp    []time.Duration
+	}{
+		{
+			name:   "should apply the backoff",
+			factor: 2,
+			exp:    []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second},
+		},
+		{
+			name:   "should apply the maximum delay",
+			factor: 2,
+			max:    3 * time.Second,
+			exp:    []time.Duration{0, time.Second, 3 * time.Second, 6 * time.Second},
+		},
+		{
+			name:   "should ignore factors less than one",
+			factor: 0.5,
+			exp:    []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			synctest.Test(t, func(t *testing.T) {
+				var act []time.Duration
+				start := time.Now()
+
+				healthy.Wait(healthy.CheckFunc(func(ctx context.Context) error {
+					act = append(act, time.Since(start))
+					if len(act) == len(tt.exp) {
+						return nil
+					}
+					return errors.New("error")
+				}), healthy.WithBackoff(tt.factor, tt.max))
+
+				if !slices.Equal(act, tt.exp) {
+					t.Errorf("got %v, expected %v", act, tt.exp)
+				}
+			})
+		})
+	}
+}
+
 func TestJoinOptions(t *testing.T) {
 	t.Run("should join the options", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
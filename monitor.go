@@ -0,0 +1,186 @@
+package healthy
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+type (
+	// Monitor represents a long-running monitor of named checks.
+	// Each check is executed in the background at the configured interval
+	// and the latest result is made available to callers and subscribers.
+	Monitor struct {
+		mu       sync.RWMutex
+		checks   map[string]Check
+		results  map[string]Result
+		subs     map[int]SubscriberFunc
+		nextSub  int
+		interval time.Duration
+		timeout  time.Duration
+	}
+
+	// SubscriberFunc represents a monitor subscriber function.
+	// The function is invoked with the check name and result after each
+	// check execution.
+	SubscriberFunc func(name string, r Result)
+)
+
+const mdKeyName = "name"
+
+// NewMonitor returns a new check monitor.
+// The default interval is 10 seconds with a 5 second check timeout.
+func NewMonitor() *Monitor {
+	return &Monitor{
+		checks:   map[string]Check{},
+		results:  map[string]Result{},
+		subs:     map[int]SubscriberFunc{},
+		interval: 10 * time.Second,
+		timeout:  5 * time.Second,
+	}
+}
+
+// Interval specifies the delay between check executions.
+func (m *Monitor) Interval(d time.Duration) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.interval = d
+	return m
+}
+
+// Timeout specifies the timeout for each check execution.
+// A zero value indicates no timeout.
+func (m *Monitor) Timeout(t time.Duration) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timeout = t
+	return m
+}
+
+// Add adds the named check to the monitor.
+// Checks must be added before the monitor is run.
+func (m *Monitor) Add(name string, c Check) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checks[name] = c
+	return m
+}
+
+// Names returns the sorted check names.
+func (m *Monitor) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Sorted(maps.Keys(m.checks))
+}
+
+// Result returns the latest result for the named check.
+// The function returns false if the check has not yet been executed.
+func (m *Monitor) Result(name string) (Result, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.results[name]
+	return r, ok
+}
+
+// Results returns the latest result for each executed check.
+func (m *Monitor) Results() map[string]Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.results)
+}
+
+// Subscribe registers the function to be invoked after each check execution.
+// The function is invoked synchronously and should not block.
+// The returned function removes the subscription.
+func (m *Monitor) Subscribe(fn SubscriberFunc) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextSub
+	m.nextSub++
+	m.subs[id] = fn
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.subs, id)
+	}
+}
+
+// Run executes the checks until the context is cancelled.
+// The function blocks and returns the context cancellation cause.
+func (m *Monitor) Run(ctx context.Context) error {
+	m.mu.RLock()
+	checks := maps.Clone(m.checks)
+	m.mu.RUnlock()
+
+	wg := new(sync.WaitGroup)
+	for name, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.run(ctx, name, c)
+		}()
+	}
+
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+// ServeHTTP writes the latest results as application/health+json.
+func (m *Monitor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	results := m.Results()
+
+	rs := make([]Result, 0, len(results))
+	for _, name := range slices.Sorted(maps.Keys(results)) {
+		rs = append(rs, results[name])
+	}
+
+	NewHealthResponse(rs...).ServeHTTP(w, r)
+}
+
+func (m *Monitor) run(ctx context.Context, name string, c Check) {
+	md := Metadata{mdKeyName: name}
+
+	for {
+		m.mu.RLock()
+		interval, timeout := m.interval, m.timeout
+		m.mu.RUnlock()
+
+		r := m.execute(SetContextMetadata(ctx, md), c, timeout)
+		if ctx.Err() != nil {
+			return
+		}
+		r.Metadata.Set(mdKeyName, name)
+		m.publish(name, r)
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Monitor) execute(ctx context.Context, c Check, timeout time.Duration) Result {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return Run(ctx, c)
+}
+
+func (m *Monitor) publish(name string, r Result) {
+	m.mu.Lock()
+	m.results[name] = r
+	subs := slices.Collect(maps.Values(m.subs))
+	m.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(name, r)
+	}
+}
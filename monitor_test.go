@@ -0,0 +1,158 @@
+package healthy_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestMonitor_Run(t *testing.T) {
+	t.Run("should execute the checks at the interval", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			var n int32
+			m := healthy.NewMonitor().Interval(time.Second).Add("a", healthy.CheckFunc(func(ctx context.Context) error {
+				atomic.AddInt32(&n, 1)
+				return nil
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+			defer cancel()
+
+			err := m.Run(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+			}
+			if act, exp := atomic.LoadInt32(&n), int32(3); act != exp {
+				t.Errorf("got %d executions, expected %d", act, exp)
+			}
+		})
+	})
+
+	t.Run("should apply the timeout", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			m := healthy.NewMonitor().Timeout(time.Second).Add("a", healthy.CheckFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go m.Run(ctx)
+
+			time.Sleep(time.Second)
+			synctest.Wait()
+
+			r, ok := m.Result("a")
+			if !ok || !errors.Is(r.Err, context.DeadlineExceeded) {
+				t.Errorf("got %v, expected %v", r.Err, context.DeadlineExceeded)
+			}
+		})
+	})
+}
+
+func TestMonitor_Result(t *testing.T) {
+	t.Run("should return the latest result", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			exp := errors.New("error")
+			m := healthy.NewMonitor().
+				Add("pass", healthy.WithMetadata(func(ctx context.Context) error { return nil }, "name", "other")).
+				Add("fail", healthy.CheckFunc(func(ctx context.Context) error { return exp }))
+
+			if _, ok := m.Result("pass"); ok {
+				t.Error("got true, expected false")
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go m.Run(ctx)
+			synctest.Wait()
+
+			r, ok := m.Result("pass")
+			if !ok || r.Err != nil {
+				t.Errorf("got %v, expected nil", r.Err)
+			}
+			if act, exp := r.Metadata.Get("name"), "pass"; act != exp {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+
+			r, ok = m.Result("fail")
+			if !ok || r.Err != exp {
+				t.Errorf("got %v, expected %v", r.Err, exp)
+			}
+
+			if act, exp := len(m.Results()), 2; act != exp {
+				t.Errorf("got %d results, expected %d", act, exp)
+			}
+			if act, exp := m.Names(), []string{"fail", "pass"}; !slices.Equal(act, exp) {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+		})
+	})
+}
+
+func TestMonitor_Subscribe(t *testing.T) {
+	t.Run("should invoke the subscriber until unsubscribed", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			m := healthy.NewMonitor().Interval(time.Second).Add("a", healthy.CheckFunc(func(ctx context.Context) error {
+				return nil
+			}))
+
+			var n int32
+			unsubscribe := m.Subscribe(func(name string, r healthy.Result) {
+				atomic.AddInt32(&n, 1)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go m.Run(ctx)
+
+			synctest.Wait()
+			unsubscribe()
+			time.Sleep(2 * time.Second)
+			synctest.Wait()
+
+			if act, exp := atomic.LoadInt32(&n), int32(1); act != exp {
+				t.Errorf("got %d invocations, expected %d", act, exp)
+			}
+		})
+	})
+}
+
+func TestMonitor_ServeHTTP(t *testing.T) {
+	t.Run("should write the latest results", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			m := healthy.NewMonitor().Add("a", healthy.CheckFunc(func(ctx context.Context) error {
+				return errors.New("error")
+			}))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go m.Run(ctx)
+			synctest.Wait()
+
+			rec := httptest.NewRecorder()
+			m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if act, exp := rec.Code, http.StatusServiceUnavailable; act != exp {
+				t.Errorf("got %d, expected %d", act, exp)
+			}
+
+			var res healthy.HealthResponse
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := res.Checks["a:responseTime"]; !ok {
+				t.Errorf("got %v, expected a:responseTime", res.Checks)
+			}
+		})
+	})
+}
@@ -0,0 +1,592 @@
+package healthy
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParseFunc represents a check specification parse function.
+// The function should return a check for the supplied URL, using query
+// parameters to configure the check.
+type ParseFunc func(u *url.URL) (Check, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]ParseFunc{
+		"tcp":           parseTCP,
+		"udp":           parseUDP,
+		"dns":           parseDNS,
+		"http":          parseHTTP,
+		"https":         parseHTTP,
+		"file":          parseFile,
+		"dir":           parseDir,
+		"log":           parseLog,
+		"mysql":         parseMySQL,
+		"mongodb":       parseMongo,
+		"kafka":         parseKafka,
+		"amqp":          parseAMQP,
+		"amqps":         parseAMQP,
+		"nats":          parseNATS,
+		"mqtt":          parseMQTT,
+		"memcached":     parseMemcached,
+		"zookeeper":     parseZooKeeper,
+		"banner":        parseBanner,
+		"ftp":           parseBanner,
+		"ssh":           parseBanner,
+		"smtp":          parseSMTP,
+		"elasticsearch": parseElasticsearch,
+		"opensearch":    parseElasticsearch,
+		"docker":        parseDocker,
+		"unix":          parseUnix,
+	}
+)
+
+// RegisterScheme registers the parse function for the URL scheme.
+// Existing registrations, including the built-in schemes, are replaced.
+func RegisterScheme(scheme string, fn ParseFunc) {
+	if fn == nil {
+		panic("fn must not be nil")
+	}
+
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[scheme] = fn
+}
+
+// Parse returns the check for the supplied URL specification, for example
+// "tcp://db:5432?timeout=2s" or "http://api:8080/health?expect=204".
+// HTTP checks can be made over a unix domain socket using the socket
+// parameter, for example "http://docker/_ping?socket=/var/run/docker.sock".
+func Parse(spec string) (Check, error) {
+	u, err := parseURL(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid check: %w", err)
+	}
+
+	// the spec is redacted as it may contain credentials
+	spec = u.Redacted()
+
+	parsersMu.RLock()
+	fn, ok := parsers[u.Scheme]
+	parsersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("invalid check %q: unsupported scheme %q", spec, u.Scheme)
+	}
+
+	c, err := fn(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid check %q: %w", spec, err)
+	}
+
+	return c, nil
+}
+
+func isRegisteredScheme(scheme string) bool {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	_, ok := parsers[scheme]
+	return ok
+}
+
+// parseURL parses the raw URL. The URL is omitted from parse errors as it
+// may contain credentials.
+func parseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if ue := (*url.Error)(nil); errors.As(err, &ue) {
+		return nil, ue.Err
+	}
+	return u, err
+}
+
+func parseTCP(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := TCP(u.Host)
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseUDP(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := UDP(u.Host)
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+	q.string("payload", func(s string) { c.Payload([]byte(s)) })
+	q.string("expect", func(s string) { c.Expect([]byte(s)) })
+
+	return c, q.close()
+}
+
+func parseDNS(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := DNS(u.Hostname())
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+	q.string("type", func(s string) { c.Type(s) })
+	q.string("resolver", func(s string) { c.Resolver(s) })
+	q.string("contains", func(s string) { c.Contains(strings.Split(s, ",")...) })
+	q.int("min", func(n int) { c.MinRecords(n) })
+
+	return c, q.close()
+}
+
+func parseHTTP(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := HTTP(u.String())
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+	q.int("expect", func(n int) { c.Expect(n) })
+	q.string("socket", func(s string) { c.Unix(s) })
+
+	// remaining query parameters are part of the target url
+	t := *u
+	t.RawQuery = q.values.Encode()
+	c.url = t.String()
+
+	return c, q.err
+}
+
+func parseKafka(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := Kafka(strings.Split(u.Host, ",")...)
+
+	q := newSpecQuery(u)
+	q.string("topics", func(s string) { c.Topics(strings.Split(s, ",")...) })
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseAMQP(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	t := *u
+	t.RawQuery = ""
+	c := AMQP(t.String())
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	q := newSpecQuery(u)
+	q.string("queue", func(s string) { c.Queue(s) })
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseNATS(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := NATS(u.Host)
+	if u.User != nil {
+		// a user without a password is treated as a token
+		if p, ok := u.User.Password(); ok {
+			c.Credentials(u.User.Username(), p)
+		} else {
+			c.Token(u.User.Username())
+		}
+	}
+
+	q := newSpecQuery(u)
+	q.bool("jetstream", func(b bool) {
+		if b {
+			c.JetStream()
+		}
+	})
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseMQTT(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := MQTT(u.Host)
+	if u.User != nil {
+		p, _ := u.User.Password()
+		c.Credentials(u.User.Username(), p)
+	}
+
+	q := newSpecQuery(u)
+	q.parse("version", func(s string) error {
+		switch s {
+		case "3.1.1":
+			c.Version(MQTT311)
+		case "5":
+			c.Version(MQTT5)
+		default:
+			return errors.New("unsupported version")
+		}
+		return nil
+	})
+	q.string("client_id", func(s string) { c.ClientID(s) })
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseMemcached(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := Memcached(u.Host)
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseZooKeeper(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := ZooKeeper(u.Host)
+
+	// an empty states parameter accepts the default serving states
+	q := newSpecQuery(u)
+	q.string("states", func(s string) {
+		if s == "" {
+			c.ServerState()
+			return
+		}
+		c.ServerState(strings.Split(s, ",")...)
+	})
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseBanner(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	q := newSpecQuery(u)
+
+	var c *BannerCheck
+	switch u.Scheme {
+	case "ftp":
+		c = FTP(u.Host)
+	case "ssh":
+		c = SSH(u.Host)
+	default:
+		var re *regexp.Regexp
+		q.parse("matches", func(s string) (err error) {
+			re, err = regexp.Compile(s)
+			return err
+		})
+		if re == nil {
+			return nil, errors.Join(q.err, errors.New("matches is required"))
+		}
+		c = Banner(u.Host, re)
+	}
+
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseSMTP(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := SMTP(u.Host)
+
+	q := newSpecQuery(u)
+	q.string("ehlo", func(s string) { c.EHLO(s) })
+	q.bool("starttls", func(b bool) {
+		if b {
+			c.StartTLS(nil)
+		}
+	})
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseElasticsearch(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	q := newSpecQuery(u)
+
+	t := url.URL{Scheme: "http", User: u.User, Host: u.Host, Path: u.Path}
+	q.bool("tls", func(b bool) {
+		if b {
+			t.Scheme = "https"
+		}
+	})
+
+	c := Elasticsearch(t.String())
+	q.parse("min_status", func(s string) error {
+		if s != "green" && s != "yellow" {
+			return errors.New("unsupported status")
+		}
+		c.MinStatus(s)
+		return nil
+	})
+	q.string("indices", func(s string) { c.Indices(strings.Split(s, ",")...) })
+	q.duration("wait", func(d time.Duration) { c.Wait(d) })
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseDocker(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("container is required")
+	}
+
+	c := DockerContainer(u.Host)
+
+	q := newSpecQuery(u)
+	q.string("socket", func(s string) { c.Socket(s) })
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseFile(u *url.URL) (Check, error) {
+	p := u.Host + u.Path
+	if p == "" {
+		return nil, errors.New("path is required")
+	}
+
+	c := File(p)
+
+	q := newSpecQuery(u)
+	q.string("contains", func(s string) { c.Contains(s) })
+	q.parse("matches", func(s string) error {
+		re, err := regexp.Compile(s)
+		if err == nil {
+			c.Matches(re)
+		}
+		return err
+	})
+	q.int("min_size", func(n int) { c.MinSize(int64(n)) })
+	q.duration("modified_within", func(d time.Duration) { c.ModifiedWithin(d) })
+	q.bool("dir", func(b bool) {
+		if b {
+			c.IsDir()
+		}
+	})
+	q.bool("not_exists", func(b bool) {
+		if b {
+			c.NotExists()
+		}
+	})
+
+	return c, q.close()
+}
+
+func parseDir(u *url.URL) (Check, error) {
+	p := u.Host + u.Path
+	if p == "" {
+		return nil, errors.New("path is required")
+	}
+
+	c := Dir(p)
+
+	q := newSpecQuery(u)
+	q.string("match", func(s string) { c.Match(s) })
+	q.int("min", func(n int) { c.MinCount(n) })
+	q.bool("stable", func(b bool) {
+		if b {
+			c.Stable()
+		}
+	})
+
+	return c, q.close()
+}
+
+func parseLog(u *url.URL) (Check, error) {
+	p := u.Host + u.Path
+	if p == "" {
+		return nil, errors.New("path is required")
+	}
+
+	q := newSpecQuery(u)
+
+	var re *regexp.Regexp
+	q.parse("match", func(s string) (err error) {
+		re, err = regexp.Compile(s)
+		return err
+	})
+	if re == nil {
+		return nil, errors.Join(q.err, errors.New("match is required"))
+	}
+
+	c := LogLine(p, re)
+	q.parse("offset", func(s string) error {
+		if s == "end" {
+			c.FromEnd()
+			return nil
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err == nil {
+			c.Offset(n)
+		}
+		return err
+	})
+
+	return c, q.close()
+}
+
+func parseMySQL(u *url.URL) (Check, error) {
+	t := *u
+	t.RawQuery = ""
+
+	c, err := parseMySQLDSN(t.String())
+	if err != nil {
+		return nil, err
+	}
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+func parseMongo(u *url.URL) (Check, error) {
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+
+	c := Mongo(u.Scheme + "://" + u.Host)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	// remaining query parameters are connection options
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+	q.bool("primary", func(b bool) {
+		if b {
+			c.RequirePrimary()
+		}
+	})
+
+	return c, q.err
+}
+
+func parseUnix(u *url.URL) (Check, error) {
+	p := u.Host + u.Path
+	if p == "" {
+		return nil, errors.New("path is required")
+	}
+
+	c := Unix(p)
+
+	q := newSpecQuery(u)
+	q.duration("timeout", func(d time.Duration) { c.Timeout(d) })
+
+	return c, q.close()
+}
+
+type specQuery struct {
+	values url.Values
+	err    error
+}
+
+func newSpecQuery(u *url.URL) *specQuery {
+	return &specQuery{values: u.Query()}
+}
+
+func (q *specQuery) string(key string, fn func(string)) {
+	q.parse(key, func(s string) error {
+		fn(s)
+		return nil
+	})
+}
+
+func (q *specQuery) duration(key string, fn func(time.Duration)) {
+	q.parse(key, func(s string) error {
+		d, err := time.ParseDuration(s)
+		if err == nil {
+			fn(d)
+		}
+		return err
+	})
+}
+
+func (q *specQuery) bool(key string, fn func(bool)) {
+	q.parse(key, func(s string) error {
+		b, err := strconv.ParseBool(s)
+		if err == nil {
+			fn(b)
+		}
+		return err
+	})
+}
+
+func (q *specQuery) int(key string, fn func(int)) {
+	q.parse(key, func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err == nil {
+			fn(n)
+		}
+		return err
+	})
+}
+
+func (q *specQuery) parse(key string, fn func(string) error) {
+	if !q.values.Has(key) {
+		return
+	}
+
+	s := q.values.Get(key)
+	q.values.Del(key)
+
+	if err := fn(s); err != nil {
+		q.err = errors.Join(q.err, fmt.Errorf("invalid %s %q", key, s))
+	}
+}
+
+// close returns an error if any query parameters were not consumed.
+func (q *specQuery) close() error {
+	for _, k := range slices.Sorted(maps.Keys(q.values)) {
+		q.err = errors.Join(q.err, fmt.Errorf("unsupported parameter %q", k))
+	}
+	return q.err
+}
@@ -0,0 +1,48 @@
+package healthy
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// Result represents the result of a single check execution.
+type Result struct {
+	Metadata Metadata
+	Err      error
+	Time     time.Time
+	Duration time.Duration
+}
+
+// Run executes the check once and returns the result.
+// The result metadata contains the context metadata, the check metadata
+// and any values set by the check during execution.
+func Run(ctx context.Context, c Check) Result {
+	md := maps.Clone(GetContextMetadata(ctx))
+	if mc, ok := c.(MetadataCheck); ok {
+		maps.Copy(md, mc.Metadata())
+	}
+
+	start := time.Now()
+	err := c.Healthy(SetContextMetadata(ctx, md))
+
+	return Result{
+		Metadata: md,
+		Err:      err,
+		Time:     start,
+		Duration: time.Since(start),
+	}
+}
+
+// Status returns the result status.
+// Warnings are reported as [HealthWarn] and other errors as [HealthFail].
+func (r Result) Status() HealthStatus {
+	switch {
+	case r.Err == nil:
+		return HealthPass
+	case IsWarn(r.Err) && !IsFatal(r.Err):
+		return HealthWarn
+	default:
+		return HealthFail
+	}
+}
@@ -0,0 +1,96 @@
+package healthy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("should return the result", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			exp := errors.New("error")
+			c := healthy.WithMetadata(func(ctx context.Context) error {
+				healthy.GetContextMetadata(ctx).Set("role", "primary")
+				time.Sleep(10 * time.Millisecond)
+				return exp
+			}, "type", "custom")
+
+			start := time.Now()
+			act := healthy.Run(context.Background(), c)
+
+			if act.Err != exp {
+				t.Errorf("got %v, expected %v", act.Err, exp)
+			}
+			if !act.Time.Equal(start) {
+				t.Errorf("got %v, expected %v", act.Time, start)
+			}
+			if act, exp := act.Duration, 10*time.Millisecond; act != exp {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+			if act, exp := act.Metadata.Get("type"), "custom"; act != exp {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+			if act, exp := act.Metadata.Get("role"), "primary"; act != exp {
+				t.Errorf("got %v, expected %v", act, exp)
+			}
+		})
+	})
+
+	t.Run("should not modify the context metadata", func(t *testing.T) {
+		md := healthy.Metadata{"a": "b"}
+		ctx := healthy.SetContextMetadata(context.Background(), md)
+
+		act := healthy.Run(ctx, healthy.WithMetadata(func(ctx context.Context) error {
+			return nil
+		}, "type", "custom"))
+
+		if act, exp := act.Metadata.Get("a"), "b"; act != exp {
+			t.Errorf("got %v, expected %v", act, exp)
+		}
+		if act := md.Get("type"); act != nil {
+			t.Errorf("got %v, expected nil", act)
+		}
+	})
+}
+
+func TestResult_Status(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		exp  healthy.HealthStatus
+	}{
+		{
+			name: "should return pass on nil error",
+			exp:  healthy.HealthPass,
+		},
+		{
+			name: "should return warn on warning",
+			err:  healthy.Warn(errors.New("warning")),
+			exp:  healthy.HealthWarn,
+		},
+		{
+			name: "should return fail on error",
+			err:  errors.New("error"),
+			exp:  healthy.HealthFail,
+		},
+		{
+			name: "should return fail on fatal warning",
+			err:  healthy.Fatal(healthy.Warn(errors.New("warning"))),
+			exp:  healthy.HealthFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := healthy.Result{Err: tt.err}.Status()
+			if act != tt.exp {
+				t.Errorf("got %s, expected %s", act, tt.exp)
+			}
+		})
+	}
+}
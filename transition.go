@@ -0,0 +1,147 @@
+package healthy
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Transition represents a change in check status.
+	// From is empty for the first non-passing result of a check.
+	Transition struct {
+		Name   string
+		From   HealthStatus
+		To     HealthStatus
+		Time   time.Time
+		Result Result
+	}
+
+	// TransitionFunc represents a transition notification function.
+	TransitionFunc func(t Transition)
+
+	// TransitionFilter represents a monitor subscriber that only notifies
+	// on check status transitions.
+	TransitionFilter struct {
+		mu         sync.Mutex
+		fn         TransitionFunc
+		debounce   int
+		flapLimit  int
+		flapWindow time.Duration
+		states     map[string]*transitionState
+	}
+
+	transitionState struct {
+		notified  HealthStatus
+		candidate HealthStatus
+		count     int
+		history   []time.Time
+	}
+)
+
+// OnTransition returns a transition filter that invokes the function on
+// check status transitions. The filter should be registered with
+// [Monitor.Subscribe] using [TransitionFilter.Notify].
+func OnTransition(fn TransitionFunc) *TransitionFilter {
+	if fn == nil {
+		panic("fn must not be nil")
+	}
+
+	return &TransitionFilter{
+		fn:       fn,
+		debounce: 1,
+		states:   map[string]*transitionState{},
+	}
+}
+
+// Debounce specifies the number of consecutive results with the new status
+// required before a transition is notified.
+// The default value is one.
+func (f *TransitionFilter) Debounce(n int) *TransitionFilter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.debounce = max(n, 1)
+	return f
+}
+
+// SuppressFlapping suppresses notifications for a check once the limit of
+// transitions has been reached within the window. Notifications resume
+// once the window has elapsed if the status has changed.
+func (f *TransitionFilter) SuppressFlapping(limit int, window time.Duration) *TransitionFilter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flapLimit = limit
+	f.flapWindow = window
+	return f
+}
+
+// Notify processes the check result and invokes the transition function
+// if the check status has changed.
+func (f *TransitionFilter) Notify(name string, r Result) {
+	t, ok := f.transition(name, r)
+	if ok {
+		f.fn(t)
+	}
+}
+
+func (f *TransitionFilter) transition(name string, r Result) (Transition, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.states[name]
+	if !ok {
+		s = &transitionState{}
+		f.states[name] = s
+	}
+
+	st := r.Status()
+	if st == s.notified || (s.notified == "" && st == HealthPass) {
+		s.notified = st
+		s.count = 0
+		return Transition{}, false
+	}
+
+	if st != s.candidate {
+		s.candidate = st
+		s.count = 0
+	}
+	s.count++
+	if s.count < f.debounce {
+		return Transition{}, false
+	}
+
+	now := time.Now()
+	if f.flapLimit > 0 {
+		i := 0
+		for i < len(s.history) && now.Sub(s.history[i]) >= f.flapWindow {
+			i++
+		}
+		s.history = s.history[i:]
+
+		if len(s.history) >= f.flapLimit {
+			return Transition{}, false
+		}
+		s.history = append(s.history, now)
+	}
+
+	t := Transition{
+		Name:   name,
+		From:   s.notified,
+		To:     st,
+		Time:   now,
+		Result: r,
+	}
+
+	s.notified = st
+	s.count = 0
+
+	return t, true
+}
+
+// TransitionChannel returns a transition function that sends transitions
+// to the supplied channel. Sends block, so the channel should be buffered
+// or actively received from.
+func TransitionChannel(ch chan<- Transition) TransitionFunc {
+	return func(t Transition) {
+		ch <- t
+	}
+}
@@ -0,0 +1,156 @@
+package healthy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestTransitionFilter_Notify(t *testing.T) {
+	var (
+		pass = healthy.Result{}
+		warn = healthy.Result{Err: healthy.Warn(errors.New("warning"))}
+		fail = healthy.Result{Err: errors.New("error")}
+	)
+
+	type transition struct {
+		from, to healthy.HealthStatus
+	}
+
+	tests := []struct {
+		name    string
+		sut     func(healthy.TransitionFunc) *healthy.TransitionFilter
+		results []healthy.Result
+		delay   time.Duration
+		exp     []transition
+	}{
+		{
+			name: "should not notify initial pass",
+			sut: func(fn healthy.TransitionFunc) *healthy.TransitionFilter {
+				return healthy.OnTransition(fn)
+			},
+			results: []healthy.Result{pass, pass},
+		},
+		{
+			name: "should notify initial failure",
+			sut: func(fn healthy.TransitionFunc) *healthy.TransitionFilter {
+				return healthy.OnTransition(fn)
+			},
+			results: []healthy.Result{fail, fail},
+			exp:     []transition{{"", healthy.HealthFail}},
+		},
+		{
+			name: "should notify transitions",
+			sut: func(fn healthy.TransitionFunc) *healthy.TransitionFilter {
+				return healthy.OnTransition(fn)
+			},
+			results: []healthy.Result{pass, fail, fail, warn, pass},
+			exp: []transition{
+				{healthy.HealthPass, healthy.HealthFail},
+				{healthy.HealthFail, healthy.HealthWarn},
+				{healthy.HealthWarn, healthy.HealthPass},
+			},
+		},
+		{
+			name: "should debounce transitions",
+			sut: func(fn healthy.TransitionFunc) *healthy.TransitionFilter {
+				return healthy.OnTransition(fn).Debounce(2)
+			},
+			results: []healthy.Result{pass, fail, pass, fail, fail, fail},
+			exp:     []transition{{healthy.HealthPass, healthy.HealthFail}},
+		},
+		{
+			name: "should suppress flapping",
+			sut: func(fn healthy.TransitionFunc) *healthy.TransitionFilter {
+				return healthy.OnTransition(fn).SuppressFlapping(2, time.Minute)
+			},
+			results: []healthy.Result{pass, fail, pass, fail, pass},
+			delay:   time.Second,
+			exp: []transition{
+				{healthy.HealthPass, healthy.HealthFail},
+				{healthy.HealthFail, healthy.HealthPass},
+			},
+		},
+		{
+			name: "should resume after the flapping window",
+			sut: func(fn healthy.TransitionFunc) *healthy.TransitionFilter {
+				return healthy.OnTransition(fn).SuppressFlapping(1, time.Minute)
+			},
+			results: []healthy.Result{pass, fail, pass, pass},
+			delay:   40 * time.Second,
+			exp: []transition{
+				{healthy.HealthPass, healthy.HealthFail},
+				{healthy.HealthFail, healthy.HealthPass},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			synctest.Test(t, func(t *testing.T) {
+				var act []transition
+				sut := tt.sut(func(t healthy.Transition) {
+					act = append(act, transition{t.From, t.To})
+				})
+
+				for _, r := range tt.results {
+					sut.Notify("a", r)
+					time.Sleep(tt.delay)
+				}
+
+				if len(act) != len(tt.exp) {
+					t.Fatalf("got %v, expected %v", act, tt.exp)
+				}
+				for i := range act {
+					if act[i] != tt.exp[i] {
+						t.Errorf("got %v, expected %v", act, tt.exp)
+					}
+				}
+			})
+		})
+	}
+
+	t.Run("should track checks independently", func(t *testing.T) {
+		var act []string
+		sut := healthy.OnTransition(func(t healthy.Transition) {
+			act = append(act, t.Name)
+		})
+
+		sut.Notify("a", pass)
+		sut.Notify("b", fail)
+		sut.Notify("a", pass)
+
+		if len(act) != 1 || act[0] != "b" {
+			t.Errorf("got %v, expected [b]", act)
+		}
+	})
+}
+
+func TestTransitionChannel(t *testing.T) {
+	t.Run("should deliver monitor transitions to the channel", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			m := healthy.NewMonitor().Add("a", healthy.CheckFunc(func(ctx context.Context) error {
+				return errors.New("error")
+			}))
+
+			ch := make(chan healthy.Transition, 1)
+			m.Subscribe(healthy.OnTransition(healthy.TransitionChannel(ch)).Notify)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go m.Run(ctx)
+
+			act := <-ch
+			if act.Name != "a" || act.To != healthy.HealthFail {
+				t.Errorf("got %+v, expected a fail transition", act)
+			}
+			if act.Result.Err == nil {
+				t.Error("got nil, expected error")
+			}
+		})
+	})
+}
@@ -0,0 +1,168 @@
+package healthy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// WebhookNotifier represents an HTTP webhook transition notifier.
+	WebhookNotifier struct {
+		client  *http.Client
+		url     string
+		header  http.Header
+		onError func(error)
+		once    sync.Once
+		mu      sync.Mutex
+		closed  bool
+		queue   chan Transition
+		done    chan struct{}
+	}
+
+	webhookPayload struct {
+		Name     string       `json:"name"`
+		From     HealthStatus `json:"from,omitempty"`
+		To       HealthStatus `json:"to"`
+		Time     time.Time    `json:"time"`
+		Metadata Metadata     `json:"metadata,omitempty"`
+		Error    string       `json:"error,omitempty"`
+	}
+)
+
+const webhookQueueSize = 64
+
+// Webhook returns an HTTP webhook transition notifier.
+// Transitions are sent to the URL as a JSON POST request. Requests are
+// made in order by a background goroutine, so a slow endpoint does not
+// delay the monitor.
+func Webhook(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		client:  &http.Client{Timeout: 5 * time.Second},
+		url:     url,
+		header:  http.Header{},
+		onError: func(error) {},
+		queue:   make(chan Transition, webhookQueueSize),
+		done:    make(chan struct{}),
+	}
+}
+
+// Timeout specifies the HTTP client timeout.
+func (n *WebhookNotifier) Timeout(t time.Duration) *WebhookNotifier {
+	n.client.Timeout = t
+	return n
+}
+
+// Header specifies an additional request header, such as authorization.
+func (n *WebhookNotifier) Header(key, value string) *WebhookNotifier {
+	n.header.Add(key, value)
+	return n
+}
+
+// OnError specifies the function to be invoked if delivery fails or a
+// transition is dropped. Delivery errors are reported from the background
+// goroutine. A nil function is ignored.
+func (n *WebhookNotifier) OnError(fn func(error)) *WebhookNotifier {
+	if fn != nil {
+		n.onError = fn
+	}
+	return n
+}
+
+// Notify queues the transition to be sent to the webhook URL and returns
+// without waiting for delivery. If the queue is full or the notifier is
+// closed the transition is dropped and the error function is invoked.
+// It can be supplied to [OnTransition] as a [TransitionFunc].
+func (n *WebhookNotifier) Notify(t Transition) {
+	if err := n.enqueue(t); err != nil {
+		n.onError(err)
+	}
+}
+
+// Close waits for queued transitions to be sent and stops the background
+// goroutine. Subsequent calls have no effect.
+func (n *WebhookNotifier) Close() {
+	n.once.Do(n.start)
+
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+
+	<-n.done
+}
+
+func (n *WebhookNotifier) enqueue(t Transition) error {
+	n.once.Do(n.start)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return errors.New("webhook notifier is closed")
+	}
+
+	select {
+	case n.queue <- t:
+		return nil
+	default:
+		return errors.New("webhook queue is full")
+	}
+}
+
+func (n *WebhookNotifier) start() {
+	go func() {
+		defer close(n.done)
+		for t := range n.queue {
+			if err := n.send(context.Background(), t); err != nil {
+				n.onError(err)
+			}
+		}
+	}()
+}
+
+func (n *WebhookNotifier) send(ctx context.Context, t Transition) error {
+	p := webhookPayload{
+		Name:     t.Name,
+		From:     t.From,
+		To:       t.To,
+		Time:     t.Time,
+		Metadata: t.Result.Metadata,
+	}
+	if t.Result.Err != nil {
+		p.Error = t.Result.Err.Error()
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range n.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("incorrect status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
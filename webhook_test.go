@@ -0,0 +1,172 @@
+package healthy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevecallear/healthy"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	tr := healthy.Transition{
+		Name: "db",
+		From: healthy.HealthPass,
+		To:   healthy.HealthFail,
+		Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Result: healthy.Result{
+			Metadata: healthy.Metadata{"type": "tcp", "target": "db:5432"},
+			Err:      errors.New("connection refused"),
+		},
+	}
+
+	t.Run("should post the transition", func(t *testing.T) {
+		var body map[string]any
+		var auth, ctype string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			ctype = r.Header.Get("Content-Type")
+			json.NewDecoder(r.Body).Decode(&body)
+		}))
+		defer s.Close()
+
+		var err error
+		n := healthy.Webhook(s.URL).
+			Header("Authorization", "Bearer token").
+			OnError(func(e error) { err = e })
+		n.Notify(tr)
+		n.Close()
+
+		if err != nil {
+			t.Fatalf("got %v, expected nil", err)
+		}
+		if act, exp := auth, "Bearer token"; act != exp {
+			t.Errorf("got %s, expected %s", act, exp)
+		}
+		if act, exp := ctype, "application/json"; act != exp {
+			t.Errorf("got %s, expected %s", act, exp)
+		}
+
+		exp := map[string]any{
+			"name":     "db",
+			"from":     "pass",
+			"to":       "fail",
+			"time":     "2025-01-02T03:04:05Z",
+			"metadata": map[string]any{"type": "tcp", "target": "db:5432"},
+			"error":    "connection refused",
+		}
+		for k, v := range exp {
+			if m, ok := v.(map[string]any); ok {
+				for mk, mv := range m {
+					if act := body[k].(map[string]any)[mk]; act != mv {
+						t.Errorf("got %v for %s.%s, expected %v", act, k, mk, mv)
+					}
+				}
+				continue
+			}
+			if act := body[k]; act != v {
+				t.Errorf("got %v for %s, expected %v", act, k, v)
+			}
+		}
+	})
+
+	t.Run("should invoke the error function on failure", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer s.Close()
+
+		var err error
+		n := healthy.Webhook(s.URL).Timeout(time.Second).OnError(func(e error) { err = e })
+		n.Notify(tr)
+		n.Close()
+
+		if err == nil {
+			t.Error("got nil, expected error")
+		}
+	})
+
+	t.Run("should not wait for delivery", func(t *testing.T) {
+		release := make(chan struct{})
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer s.Close()
+
+		n := healthy.Webhook(s.URL)
+
+		done := make(chan struct{})
+		go func() {
+			n.Notify(tr)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("got blocked notify, expected immediate return")
+		}
+
+		close(release)
+		n.Close()
+	})
+
+	t.Run("should deliver transitions in order", func(t *testing.T) {
+		var names []string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var p struct{ Name string }
+			json.NewDecoder(r.Body).Decode(&p)
+			names = append(names, p.Name)
+		}))
+		defer s.Close()
+
+		n := healthy.Webhook(s.URL)
+		for _, name := range []string{"a", "b", "c"} {
+			tr := tr
+			tr.Name = name
+			n.Notify(tr)
+		}
+		n.Close()
+
+		if act, exp := strings.Join(names, ","), "a,b,c"; act != exp {
+			t.Errorf("got %s, expected %s", act, exp)
+		}
+	})
+
+	t.Run("should ignore a nil error function", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer s.Close()
+
+		n := healthy.Webhook(s.URL).OnError(nil)
+		n.Notify(tr)
+		n.Close()
+	})
+
+	t.Run("should drop transitions after close", func(t *testing.T) {
+		var calls int
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		}))
+		defer s.Close()
+
+		var err error
+		n := healthy.Webhook(s.URL).OnError(func(e error) { err = e })
+		n.Close()
+		n.Notify(tr)
+		n.Close()
+
+		if act, exp := fmt.Sprint(err), "webhook notifier is closed"; act != exp {
+			t.Errorf("got %s, expected %s", act, exp)
+		}
+		if calls != 0 {
+			t.Errorf("got %d, expected 0", calls)
+		}
+	})
+}